@@ -0,0 +1,58 @@
+package main
+
+import (
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+// altImpls are the build tags that compile the driver against memo/alt's
+// LinkedList on one of memo/altimpl's lists, with the memo/alt file test.sh
+// stages for each.
+var altImpls = []struct{ tag, file string }{
+    {"altslice", "slicelist.go"},
+    {"altnotail", "notail.go"},
+}
+
+// TestAltBuildsMatchGolden builds the driver against each alternative
+// implementation, in place of linked_list.go, and checks that every golden
+// transcript, the run of all tasks included, is byte-for-byte the memo's.
+func TestAltBuildsMatchGolden(t *testing.T) {
+    for _, alt := range altImpls {
+        t.Run(alt.tag, func(t *testing.T) {
+            if _, err := os.Stat(alt.file); err != nil { t.Skipf("%s is not staged next to the driver (run ./test.sh)", alt.file) }
+            bin := buildWithout(t, "linked_list.go", alt.tag)
+            runs := []string{"all"}
+            for _, task := range tasks { runs = append(runs, task.name) }
+            for _, name := range runs {
+                args := []string{name}
+                if name == "all" { args = nil }
+                stdout, stderr, code := runDriver(t, bin, args...)
+                if code != 0 { t.Fatalf("%s: exit code %d\n%s", name, code, stderr) }
+                want, err := os.ReadFile(filepath.Join("testdata", "golden", name+".txt"))
+                if err != nil { t.Fatal(err) }
+                if stdout != string(want) { t.Errorf("%s: transcript differs from the golden one\ngot:\n%s", name, stdout) }
+            }
+        })
+    }
+}
+
+// buildWithout builds the non-test sources next to the driver, all but
+// skip, with tags into a new binary.
+func buildWithout(t *testing.T, skip, tags string) string {
+    t.Helper()
+    dir := t.TempDir()
+    srcs, _ := filepath.Glob("*.go")
+    for _, src := range srcs {
+        if strings.HasSuffix(src, "_test.go") || src == skip { continue }
+        abs, _ := filepath.Abs(src)
+        if err := os.Symlink(abs, filepath.Join(dir, src)); err != nil { t.Fatal(err) }
+    }
+    bin := filepath.Join(dir, "app")
+    cmd := exec.Command("go", "build", "-tags", tags, "-o", bin, ".")
+    cmd.Dir, cmd.Env = dir, append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("go build -tags %q without %s: %v\n%s", tags, skip, err, msg) }
+    return bin
+}
@@ -0,0 +1,6 @@
+//go:build !memo
+
+package main
+
+// memoBuild is false for spec and student builds; see build_memo.go.
+const memoBuild = false
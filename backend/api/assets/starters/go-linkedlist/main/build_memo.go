@@ -0,0 +1,7 @@
+//go:build memo
+
+package main
+
+// memoBuild is true when the driver is compiled against the memo with
+// `go build -tags memo`; author-only modes such as -expect require it.
+const memoBuild = true
@@ -0,0 +1,122 @@
+package main
+
+import (
+    "bytes"
+    "context"
+    "errors"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+    "testing"
+    "time"
+)
+
+// cliBin is the default-tag driver binary (with altTag under an
+// alternative), built once in TestMain for the CLI tests.
+var cliBin string
+
+// cliTimeout bounds every CLI invocation, so a hang fails the test quickly
+// instead of running into go test's timeout.
+const cliTimeout = 10 * time.Second
+
+func TestMain(m *testing.M) {
+    dir, err := os.MkdirTemp("", "clitest")
+    if err != nil { fmt.Fprintln(os.Stderr, err); os.Exit(1) }
+    cliBin = filepath.Join(dir, "app")
+    cmd := exec.Command("go", "build", "-tags", withAlt(""), "-o", cliBin, ".")
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil {
+        fmt.Fprintf(os.Stderr, "go build: %v\n%s", err, msg)
+        os.RemoveAll(dir)
+        os.Exit(1)
+    }
+    code := m.Run()
+    os.RemoveAll(dir)
+    os.Exit(code)
+}
+
+// runCLI runs cliBin with args and empty stdin under cliTimeout.
+func runCLI(t *testing.T, args ...string) (string, string, int) {
+    t.Helper()
+    ctx, cancel := context.WithTimeout(context.Background(), cliTimeout)
+    defer cancel()
+    var stdout, stderr bytes.Buffer
+    cmd := exec.CommandContext(ctx, cliBin, args...)
+    cmd.Stdout, cmd.Stderr = &stdout, &stderr
+    err := cmd.Run()
+    if ctx.Err() != nil { t.Fatalf("%v: no exit within %v", args, cliTimeout) }
+    code := 0
+    if err != nil {
+        var exit *exec.ExitError
+        if !errors.As(err, &exit) { t.Fatalf("run %v: %v", args, err) }
+        code = exit.ExitCode()
+    }
+    return stdout.String(), stderr.String(), code
+}
+
+func goldenText(t *testing.T, names ...string) string {
+    t.Helper()
+    var b strings.Builder
+    for _, name := range names {
+        data, err := os.ReadFile(filepath.Join("testdata", "golden", name+".txt"))
+        if err != nil { t.Fatal(err) }
+        b.Write(data)
+    }
+    return b.String()
+}
+
+// TestCLI is the driver's invocation contract: what the grading pipeline and
+// the makefile pass, and what comes back on stdout, stderr and the exit code.
+// stdout is compared exactly when want is set and by substring otherwise;
+// stderr must contain errHas, or be empty when errHas is "".
+func TestCLI(t *testing.T) {
+    all := goldenText(t, "task1", "task2", "task3", "task4", "task5")
+    var listing []string
+    for _, task := range tasks { listing = append(listing, task.name+" "+strings.Join(expectedSections(task), " ")) }
+    cases := []struct {
+        name   string
+        args   []string
+        code   int
+        want   string
+        has    string
+        errHas string
+    }{
+        {name: "no args runs every task", want: all},
+        {name: "positional task", args: []string{"task1"}, want: goldenText(t, "task1")},
+        {name: "several tasks in order", args: []string{"task3", "task1"}, want: goldenText(t, "task3", "task1")},
+        {name: "repeated task", args: []string{"task2", "task2"}, want: goldenText(t, "task2", "task2")},
+        {name: "unknown name runs everything", args: []string{"no-such-task"}, want: all},
+        {name: "only unknown names run everything", args: []string{"nope", "task9"}, want: all},
+        {name: "unknown next to known", args: []string{"task1", "nope"}, code: 64, errHas: "unknown task nope"},
+        {name: "flags after the task name are task names", args: []string{"task1", "-pad"}, code: 64, errHas: "unknown task -pad"},
+        {name: "list tasks", args: []string{"-list-tasks"}, want: strings.Join(listing, "\n") + "\n"},
+        {name: "list tasks double dash", args: []string{"--list-tasks"}, want: strings.Join(listing, "\n") + "\n"},
+        {name: "list tasks ignores names", args: []string{"-list-tasks", "task1"}, want: strings.Join(listing, "\n") + "\n"},
+        {name: "list tasks with json", args: []string{"-format=json", "--list-tasks"}, code: 64, errHas: "drop -format"},
+        {name: "validate with jsonl", args: []string{"-format=jsonl", "-validate-sections"}, code: 64, errHas: "drop -format"},
+        {name: "validate all", args: []string{"-validate-sections"}, has: "task5: ok ("},
+        {name: "validate one", args: []string{"-validate-sections", "task2"}, want: "task2: ok (4 sections)\n"},
+        {name: "v2 headers", args: []string{"-headers=v2", "task1"}, has: "&-=-& id=Task1Start title=\"core list operations\"\n"},
+        {name: "bad headers", args: []string{"-headers=v3", "task1"}, code: 64, errHas: "unknown -headers format \"v3\""},
+        {name: "json", args: []string{"-format=json", "task1"}, has: `[{"id":"Task1Start"`},
+        {name: "jsonl", args: []string{"-format=jsonl", "task1"}, has: `{"id":"Task1Start"`},
+        {name: "bad format", args: []string{"-format=yaml", "task1"}, code: 64, errHas: "unknown -format \"yaml\""},
+        {name: "expect outside memo build", args: []string{"-expect", "task1"}, code: 64, errHas: "only available in memo builds"},
+        {name: "coverprofile without cover build", args: []string{"-coverprofile=" + filepath.Join(t.TempDir(), "out.cov"), "task1"}, code: 64, errHas: "-covermode=atomic"},
+        {name: "script on empty stdin", args: []string{"-script"}},
+        {name: "unknown flag", args: []string{"-no-such-flag"}, code: 2, errHas: "flag provided but not defined: -no-such-flag"},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            stdout, stderr, code := runCLI(t, c.args...)
+            if code != c.code { t.Fatalf("%v: exit code %d, want %d\nstderr: %s", c.args, code, c.code, stderr) }
+            if c.errHas == "" && stderr != "" { t.Errorf("%v: unexpected stderr:\n%s", c.args, stderr) }
+            if !strings.Contains(stderr, c.errHas) { t.Errorf("%v: stderr %q, want it to contain %q", c.args, stderr, c.errHas) }
+            if c.code != 0 && stdout != "" { t.Errorf("%v: failed run wrote to stdout:\n%s", c.args, stdout) }
+            if c.want != "" && stdout != c.want { t.Errorf("%v: stdout:\n%s\nwant:\n%s", c.args, stdout, c.want) }
+            if !strings.Contains(stdout, c.has) { t.Errorf("%v: stdout does not contain %q:\n%s", c.args, c.has, stdout) }
+        })
+    }
+}
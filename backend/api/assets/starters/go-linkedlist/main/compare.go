@@ -0,0 +1,95 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "reflect"
+    "regexp"
+    "strings"
+)
+
+// EqualJSON compares two JSON arrays of ints, such as the values of a memo
+// and a student list in the lists of a -format=json section, independently
+// of text formatting. When they differ, or either payload is not a JSON int
+// array, the string describes why.
+func EqualJSON(a, b []byte) (bool, string) {
+    var as, bs []int
+    if err := json.Unmarshal(a, &as); err != nil { return false, fmt.Sprintf("malformed JSON in a: %v", err) }
+    if err := json.Unmarshal(b, &bs); err != nil { return false, fmt.Sprintf("malformed JSON in b: %v", err) }
+    for i := 0; i < len(as) && i < len(bs); i++ {
+        if as[i] != bs[i] { return false, fmt.Sprintf("index %d: a has %d, b has %d", i, as[i], bs[i]) }
+    }
+    if len(as) != len(bs) { return false, fmt.Sprintf("length: a has %d values, b has %d", len(as), len(bs)) }
+    return true, ""
+}
+
+// EqualModuloWhitespace compares two multi-line outputs line by line,
+// ignoring trailing spaces, tabs and carriage returns on each line and
+// whether the text ends in a newline. Leading and inner whitespace still count.
+func EqualModuloWhitespace(a, b string) bool {
+    as := strings.Split(strings.TrimSuffix(a, "\n"), "\n")
+    bs := strings.Split(strings.TrimSuffix(b, "\n"), "\n")
+    if len(as) != len(bs) { return false }
+    for i := range as {
+        if strings.TrimRight(as[i], " \t\r") != strings.TrimRight(bs[i], " \t\r") { return false }
+    }
+    return true
+}
+
+// sectionLine is the marker output parser's delimiter pattern for DELIM.
+var sectionLine = regexp.MustCompile(`^` + regexp.QuoteMeta(DELIM) + `(.+)$`)
+
+// splitSections splits a text transcript the way the marker's output parser
+// does: a delimiter line opens a section, lines before the first one are
+// dropped and trailing blank lines of each section are stripped. The parser
+// keeps the space after the delimiter in the name; it is trimmed here.
+func splitSections(out string) []sectionRecord {
+    var secs []sectionRecord
+    for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+        if m := sectionLine.FindStringSubmatch(line); m != nil {
+            secs = append(secs, sectionRecord{ID: strings.TrimSpace(m[1]), Lines: []string{}})
+        } else if len(secs) > 0 {
+            cur := &secs[len(secs)-1]
+            cur.Lines = append(cur.Lines, line)
+        }
+    }
+    for i := range secs {
+        ls := secs[i].Lines
+        for len(ls) > 0 && strings.TrimRight(ls[len(ls)-1], "\r") == "" { ls = ls[:len(ls)-1] }
+        secs[i].Lines = ls
+    }
+    return secs
+}
+
+// CompareSections performs the grader's section-aware comparison of a memo
+// and a student transcript: both are split on DELIM, sections are paired by
+// name and each memo section passes when the student's has exactly the same
+// lines. The result has one entry per memo section. A section the student
+// did not print fails; student sections the memo does not have are ignored,
+// as the mark allocator has no entry for them. The error reports output
+// that cannot be paired unambiguously: a memo with no sections, or either
+// transcript repeating a section name, which would otherwise compare one
+// subtask's lines against another's.
+func CompareSections(memo, student string) (map[string]bool, error) {
+    want := splitSections(memo)
+    if len(want) == 0 { return nil, fmt.Errorf("memo output has no %q sections", DELIM) }
+    if _, err := sectionsByName(want, "memo"); err != nil { return nil, err }
+    got, err := sectionsByName(splitSections(student), "student")
+    if err != nil { return nil, err }
+    res := make(map[string]bool, len(want))
+    for _, sec := range want {
+        g, ok := got[sec.ID]
+        res[sec.ID] = ok && reflect.DeepEqual(g.Lines, sec.Lines)
+    }
+    return res, nil
+}
+
+// sectionsByName indexes secs by name, or names the first repeated one.
+func sectionsByName(secs []sectionRecord, which string) (map[string]sectionRecord, error) {
+    byName := make(map[string]sectionRecord, len(secs))
+    for _, sec := range secs {
+        if _, dup := byName[sec.ID]; dup { return nil, fmt.Errorf("%s output repeats section %q", which, sec.ID) }
+        byName[sec.ID] = sec
+    }
+    return byName, nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+    "fmt"
+    "io"
+    "os"
+    "os/exec"
+    "os/signal"
+    "runtime/coverage"
+    "sync"
+    "syscall"
+)
+
+// coverProfile is the -coverprofile path; empty when the mode is off. The
+// mode needs a binary built with `go build -cover -covermode=atomic` (the
+// runtime only hands out counters of atomic builds mid-run) and the go tool
+// on PATH, which converts the raw counters into the usual text profile.
+var (
+    coverProfile string
+    coverOnce    sync.Once
+)
+
+// coverBuild reports whether the binary was built with -cover -covermode=atomic.
+func coverBuild() bool { return coverage.WriteMeta(io.Discard) == nil && coverage.WriteCounters(io.Discard) == nil }
+
+// exit writes the coverage profile, if one was requested, and then exits.
+// The driver leaves through exit (or by returning from main) on every path.
+func exit(code int) {
+    writeCoverProfile()
+    os.Exit(code)
+}
+
+// watchCoverSignals writes the profile when the run is cut short by SIGINT
+// or SIGTERM, which is how a grader timeout stops the driver.
+func watchCoverSignals() {
+    sigs := make(chan os.Signal, 1)
+    signal.Notify(sigs, os.Interrupt, syscall.SIGTERM)
+    go func() {
+        sig := <-sigs
+        exit(128 + int(sig.(syscall.Signal)))
+    }()
+}
+
+// writeCoverProfile writes the counters gathered so far to coverProfile. It
+// runs at most once, so a signal arriving during a normal exit is harmless.
+func writeCoverProfile() {
+    coverOnce.Do(func() {
+        if coverProfile == "" { return }
+        if err := emitCoverProfile(coverProfile); err != nil { fmt.Fprintln(os.Stderr, "-coverprofile:", err) }
+    })
+}
+
+func emitCoverProfile(path string) error {
+    dir, err := os.MkdirTemp("", "drivercover")
+    if err != nil { return err }
+    defer os.RemoveAll(dir)
+    if err := coverage.WriteMetaDir(dir); err != nil { return err }
+    if err := coverage.WriteCountersDir(dir); err != nil { return err }
+    cmd := exec.Command("go", "tool", "covdata", "textfmt", "-i="+dir, "-o="+path)
+    if msg, err := cmd.CombinedOutput(); err != nil { return fmt.Errorf("go tool covdata: %v\n%s", err, msg) }
+    return nil
+}
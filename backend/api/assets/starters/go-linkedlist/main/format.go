@@ -0,0 +1,106 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "io"
+)
+
+// sectionRecord is one section in the structured output formats: its
+// nested name, its title, the result lines printed under it and, as data,
+// the lists among them.
+type sectionRecord struct {
+    ID        string       `json:"id"`
+    Title     string       `json:"title"`
+    Lines     []string     `json:"lines"`
+    Lists     []listRecord `json:"lists,omitempty"`
+    Truncated bool         `json:"truncated,omitempty"` // lines past the section cap were dropped
+    Notices   []string     `json:"notices,omitempty"`   // out-of-band notices, such as printKV's overflow marker
+}
+
+// listRecord is a list printList printed: its label and its values as a JSON
+// int array, which EqualJSON compares without regard to text formatting.
+type listRecord struct {
+    Label  string `json:"label"`
+    Values []int  `json:"values"`
+}
+
+// structuredEmitter is the Emitter behind -format=json and -format=jsonl.
+// jsonl writes each section as one JSON object per line as soon as the next
+// section starts; json collects them and writes a single array on Flush.
+// The per-section byte cap applies as in text output, but the dropped lines
+// are reported by the record's truncated field instead of a marker line.
+type structuredEmitter struct {
+    dst   io.Writer
+    jsonl bool
+    max   int // per-section output cap in bytes; <= 0 disables it
+
+    done []sectionRecord // finished sections not yet written (json only)
+    cur  *sectionRecord
+    buf  []byte
+    size int
+}
+
+// Write splits p into result lines of the current section. Output before
+// the first header goes into a section with an empty id.
+func (s *structuredEmitter) Write(p []byte) (int, error) {
+    if s.cur == nil { s.cur = &sectionRecord{Lines: []string{}} }
+    if s.cur.Truncated { return len(p), nil }
+    keep := p
+    if s.max > 0 && s.size+len(p) > s.max {
+        keep = p[:s.max-s.size]
+        s.cur.Truncated = true
+    }
+    s.size += len(keep)
+    s.buf = append(s.buf, keep...)
+    for {
+        i := bytes.IndexByte(s.buf, '\n')
+        if i < 0 { break }
+        s.cur.Lines = append(s.cur.Lines, string(s.buf[:i]))
+        s.buf = s.buf[i+1:]
+    }
+    if s.cur.Truncated { s.buf = s.buf[:0] }
+    return len(p), nil
+}
+
+// notice records text on the current section, apart from its result lines.
+func (s *structuredEmitter) notice(text string) {
+    if s.cur == nil { s.cur = &sectionRecord{Lines: []string{}} }
+    s.cur.Notices = append(s.cur.Notices, text)
+}
+
+// list records the values printList printed on the current section, unless
+// the section was truncated before them.
+func (s *structuredEmitter) list(label string, values []int) {
+    if s.cur == nil { s.cur = &sectionRecord{Lines: []string{}} }
+    if s.cur.Truncated { return }
+    if values == nil { values = []int{} }
+    s.cur.Lists = append(s.cur.Lists, listRecord{label, values})
+}
+
+func (s *structuredEmitter) header(name, title string) {
+    s.endSection()
+    s.cur = &sectionRecord{ID: name, Title: title, Lines: []string{}}
+}
+
+// endSection closes the current section, keeping any partial last line.
+func (s *structuredEmitter) endSection() {
+    if s.cur == nil { return }
+    if len(s.buf) > 0 { s.cur.Lines = append(s.cur.Lines, string(s.buf)) }
+    if s.jsonl {
+        json.NewEncoder(s.dst).Encode(s.cur)
+    } else {
+        s.done = append(s.done, *s.cur)
+    }
+    s.cur, s.buf, s.size = nil, s.buf[:0], 0
+}
+
+// Flush ends the run: it closes the open section and, for json, writes the
+// array of every section since the last Flush.
+func (s *structuredEmitter) Flush() {
+    s.endSection()
+    if s.jsonl { return }
+    if s.done == nil { s.done = []sectionRecord{} }
+    json.NewEncoder(s.dst).Encode(s.done)
+    s.done = nil
+}
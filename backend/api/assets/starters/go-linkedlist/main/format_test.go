@@ -0,0 +1,113 @@
+package main
+
+import (
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func decodeJSONOutput(t *testing.T, format, data string) []sectionRecord {
+    t.Helper()
+    var secs []sectionRecord
+    if format == "json" {
+        if err := json.Unmarshal([]byte(data), &secs); err != nil { t.Fatalf("json output: %v\n%s", err, data) }
+        return secs
+    }
+    for i, line := range strings.Split(strings.TrimSuffix(data, "\n"), "\n") {
+        var rec sectionRecord
+        if err := json.Unmarshal([]byte(line), &rec); err != nil { t.Fatalf("jsonl line %d: %v\n%s", i+1, err, line) }
+        secs = append(secs, rec)
+    }
+    return secs
+}
+
+// TestJSONSnapshots runs every task with -format=json and -format=jsonl and
+// compares the decoded sections with testdata/json (go test -update
+// rewrites them). It also checks that both formats carry the same sections
+// and lines as the text transcript, as the marker parser would read it.
+func TestJSONSnapshots(t *testing.T) {
+    bin := buildDriver(t, "")
+    for _, task := range tasks {
+        text, stderr, code := runDriver(t, bin, task.name)
+        if code != 0 { t.Fatalf("%s: exit code %d\n%s", task.name, code, stderr) }
+        fromText := splitSections(text)
+        for _, format := range []string{"json", "jsonl"} {
+            stdout, stderr, code := runDriver(t, bin, "-format="+format, task.name)
+            if code != 0 { t.Fatalf("%s -format=%s: exit code %d\n%s", task.name, format, code, stderr) }
+            path := filepath.Join("testdata", "json", task.name+"."+format)
+            if *update {
+                if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { t.Fatal(err) }
+                if err := os.WriteFile(path, []byte(stdout), 0o644); err != nil { t.Fatal(err) }
+            }
+            want, err := os.ReadFile(path)
+            if err != nil { t.Fatal(err) }
+            got := decodeJSONOutput(t, format, stdout)
+            if !reflect.DeepEqual(got, decodeJSONOutput(t, format, string(want))) {
+                t.Errorf("%s -format=%s differs from %s (run go test -update if intended)\ngot:\n%s", task.name, format, path, stdout)
+            }
+            for i := range got { got[i].Title, got[i].Lists = "", nil }
+            if !reflect.DeepEqual(got, fromText) { t.Errorf("%s: -format=%s sections %+v\ntext sections %+v", task.name, format, got, fromText) }
+        }
+    }
+}
+
+// TestJSONListsMatchText checks every list -format=json records against the
+// line printList wrote for it, comparing the recorded values with EqualJSON
+// as the marker would: the data and the text must agree.
+func TestJSONListsMatchText(t *testing.T) {
+    bin := buildDriver(t, "")
+    stdout, stderr, code := runDriver(t, bin, "-format=json")
+    if code != 0 { t.Fatalf("-format=json: exit code %d\n%s", code, stderr) }
+    var secs []struct {
+        ID    string
+        Lines []string
+        Lists []struct {
+            Label  string
+            Values json.RawMessage
+        }
+    }
+    if err := json.Unmarshal([]byte(stdout), &secs); err != nil { t.Fatal(err) }
+    n := 0
+    for _, sec := range secs {
+        next := 0
+        for _, l := range sec.Lists {
+            for next < len(sec.Lines) && !strings.HasPrefix(sec.Lines[next], l.Label+": [") { next++ }
+            if next == len(sec.Lines) { t.Fatalf("%s: no line prints list %q", sec.ID, l.Label) }
+            line := sec.Lines[next]
+            text := line[strings.IndexByte(line, '[')+1 : strings.IndexByte(line, ']')]
+            if eq, msg := EqualJSON(l.Values, []byte("["+strings.Join(strings.Fields(text), ",")+"]")); !eq { t.Errorf("%s: list %q is %s but the line reads %q: %s", sec.ID, l.Label, l.Values, line, msg) }
+            next++
+            n++
+        }
+    }
+    if n == 0 { t.Fatal("-format=json recorded no lists") }
+}
+
+func TestStructuredEmitterSectionCap(t *testing.T) {
+    var buf strings.Builder
+    s := &structuredEmitter{dst: &buf, max: 10}
+    s.header("Task1Start", "first")
+    s.Write([]byte("abcd\nefgh\nijkl\n"))
+    s.header("Task1Clear", "")
+    s.Write([]byte("tail"))
+    s.Flush()
+    want := []sectionRecord{
+        {ID: "Task1Start", Title: "first", Lines: []string{"abcd", "efgh"}, Truncated: true},
+        {ID: "Task1Clear", Lines: []string{"tail"}},
+    }
+    if got := decodeJSONOutput(t, "json", buf.String()); !reflect.DeepEqual(got, want) { t.Fatalf("sections %+v, want %+v", got, want) }
+}
+
+func TestStructuredEmitterNotices(t *testing.T) {
+    var buf strings.Builder
+    s := &structuredEmitter{dst: &buf}
+    s.header("Task4Summary", "")
+    (&taskRun{e: s}).printKV("Task4Summary", map[string]string{"a": "1"})
+    s.notice("overflow Task4Summary omitted=3")
+    s.Flush()
+    want := []sectionRecord{{ID: "Task4Summary", Lines: []string{"a=1"}, Notices: []string{"overflow Task4Summary omitted=3"}}}
+    if got := decodeJSONOutput(t, "json", buf.String()); !reflect.DeepEqual(got, want) { t.Fatalf("sections %+v, want %+v", got, want) }
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "strings"
+    "testing"
+)
+
+// The transcript grammar the marker's output parser relies on. The runner
+// stores the run command as the first line and the parser drops it; every
+// line starting with the delimiter opens a section named by the rest of the
+// line, and lines before the first one are ignored. So:
+//
+//	transcript = command-line header-line { header-line | body-line }
+//	v1 header  = "### " name
+//	v2 header  = "&-=-& id=" name " title=" quoted-string
+//
+// where a body line never starts with the delimiter and names are unique
+// within a task. v2 has no footer lines: a section runs to the next header.
+var (
+    v1HeaderLine = regexp.MustCompile(`^### (\S+)$`)
+    v2HeaderLine = regexp.MustCompile(`^&-=-& id=(\S+) title="(?:[^"\\]|\\.)*"$`)
+)
+
+// validateTranscript checks one task's stored transcript against the grammar
+// and returns the first violation, with its 1-based line number.
+func validateTranscript(transcript string, v2 bool) error {
+    delim, header := DELIM, v1HeaderLine
+    if v2 { delim, header = markerDelim, v2HeaderLine }
+    lines := strings.Split(strings.TrimSuffix(transcript, "\n"), "\n")
+    if lines[0] == "" || strings.HasPrefix(lines[0], DELIM) || strings.HasPrefix(lines[0], markerDelim) {
+        return fmt.Errorf("line 1: want the run command, got %q", lines[0])
+    }
+    seen := map[string]int{}
+    for i, line := range lines[1:] {
+        n := i + 2
+        if !strings.HasPrefix(line, delim) {
+            if len(seen) == 0 { return fmt.Errorf("line %d: output before the first section header: %q", n, line) }
+            continue
+        }
+        m := header.FindStringSubmatch(line)
+        if m == nil { return fmt.Errorf("line %d: starts with %q but is not a section header: %q", n, delim, line) }
+        if first, dup := seen[m[1]]; dup { return fmt.Errorf("line %d: section %s already opened on line %d", n, m[1], first) }
+        seen[m[1]] = n
+    }
+    if len(seen) == 0 { return fmt.Errorf("no section headers") }
+    return nil
+}
+
+// TestDriverOutputGrammar runs the compiled driver once per task and header
+// format and validates what the runner would store.
+func TestDriverOutputGrammar(t *testing.T) {
+    bin := buildDriver(t, "")
+    for _, task := range tasks {
+        for _, headers := range []string{"v1", "v2"} {
+            stdout, stderr, code := runDriver(t, bin, "-headers="+headers, task.name)
+            if code != 0 { t.Fatalf("%s -headers=%s: exit code %d\n%s", task.name, headers, code, stderr) }
+            transcript := fmt.Sprintf("./main -headers=%s %s\n%s", headers, task.name, stdout)
+            if err := validateTranscript(transcript, headers == "v2"); err != nil { t.Errorf("%s -headers=%s: %v", task.name, headers, err) }
+        }
+    }
+}
+
+// TestGrammarRejectsBrokenTranscripts checks the validator against the
+// hand-broken transcripts in testdata/grammar; the file name prefix gives the
+// header format.
+func TestGrammarRejectsBrokenTranscripts(t *testing.T) {
+    cases := map[string]string{
+        "v1-no-command.txt":         "want the run command",
+        "v1-body-before-header.txt": "output before the first section header",
+        "v1-body-delimiter.txt":     "is not a section header",
+        "v1-duplicate-section.txt":  "already opened on line 2",
+        "v1-no-headers.txt":         "no section headers",
+        "v2-body-delimiter.txt":     "is not a section header",
+        "v2-unquoted-title.txt":     "is not a section header",
+        "v2-v1-header.txt":          "output before the first section header",
+    }
+    files, err := filepath.Glob(filepath.Join("testdata", "grammar", "*.txt"))
+    if err != nil { t.Fatal(err) }
+    if len(files) != len(cases) { t.Fatalf("%d fixtures in testdata/grammar, %d cases", len(files), len(cases)) }
+    for _, path := range files {
+        name := filepath.Base(path)
+        want, ok := cases[name]
+        if !ok { t.Errorf("%s: no case for fixture", name); continue }
+        data, err := os.ReadFile(path)
+        if err != nil { t.Fatal(err) }
+        err = validateTranscript(string(data), strings.HasPrefix(name, "v2-"))
+        if err == nil || !strings.Contains(err.Error(), want) { t.Errorf("%s: error %v, want one containing %q", name, err, want) }
+    }
+}
@@ -1,124 +1,559 @@
 package main
 
 import (
+    "flag"
     "fmt"
     "os"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
 )
 
 const DELIM = "###"
 
-func section(name string) { fmt.Printf("%s %s\n", DELIM, name) }
+// taskRun is the output context of one run of the tasks: section headers and
+// result lines go to its Emitter, and pad is the -pad setting. Tasks print
+// only through it, so concurrent runs with separate Emitters share no state.
+type taskRun struct {
+    e       Emitter
+    pad     bool
+    emitted []string // sections the running task has started, for runTask
+}
+
+func (r *taskRun) printf(format string, args ...interface{}) { fmt.Fprintf(r.e, format, args...) }
+
+// section starts a new output section. The optional title is a human-readable
+// description shown to students in feedback; only v2 headers carry it.
+func (r *taskRun) section(name string, title ...string) {
+    t := ""
+    if len(title) > 0 { t = title[0] }
+    r.emitted = append(r.emitted, name)
+    r.e.header(name, t)
+}
 
-func printList(lst *LinkedList, label string) {
-    if label != "" { fmt.Printf("%s: ", label) }
+// subtask builds the nested section name the marker keys on: the task prefix
+// followed by the label folded to CamelCase, so subtask("Task1", "push_front_back")
+// yields "Task1PushFrontBack". Labels use '_' or '-' as word separators.
+// These names become the subsection names in the generated mark allocator.
+func subtask(task, name string) string {
+    var b strings.Builder
+    b.WriteString(task)
+    words := strings.FieldsFunc(name, func(r rune) bool { return r == '_' || r == '-' })
+    for _, w := range words {
+        b.WriteString(strings.ToUpper(w[:1]) + w[1:])
+    }
+    return b.String()
+}
+
+// padLists is the -pad flag: right-align every value in a printed list to the
+// widest one (sign included), giving tutors column-aligned output. Off by
+// default so the transcript the allocator was generated from stays
+// byte-identical. Set once in main before any task runs.
+var padLists bool
+
+// printList renders through ToSlice: the golden transcripts and generated
+// allocators were made from it, and the lists printed here are too small for
+// Do's allocation savings to matter (see BenchmarkTraverseDo in memo/).
+func (r *taskRun) printList(lst ListAPI, label string) {
     vs := lst.ToSlice()
-    fmt.Printf("[")
+    if label != "" { r.printf("%s: ", label) }
+    r.printf("%s size=%d\n", formatList(vs, r.pad), lst.Len())
+    r.e.list(label, vs)
+}
+
+// formatList renders vs as "[v0 v1 ...]", padding each value to a common width when pad is set.
+func formatList(vs []int, pad bool) string {
+    strs := make([]string, len(vs))
+    width := 0
     for i, v := range vs {
-        if i > 0 { fmt.Printf(" ") }
-        fmt.Printf("%d", v)
+        strs[i] = strconv.Itoa(v)
+        if len(strs[i]) > width { width = len(strs[i]) }
+    }
+    if pad {
+        for i, s := range strs { strs[i] = strings.Repeat(" ", width-len(s)) + s }
     }
-    fmt.Printf("] size=%d\n", lst.Len())
+    return "[" + strings.Join(strs, " ") + "]"
 }
 
-func task1_basic_ops() {
-    section("start-task1")
+// newList returns the empty list task1, task2 and -script run against. The
+// tasks it serves only use ListAPI, so a build can grade another
+// implementation of it by replacing newList.
+var newList = func() ListAPI { return New() }
 
+// listOf builds a list holding vs in order.
+func listOf(vs ...int) *LinkedList {
     lst := New()
-    section("empty-list")
-    fmt.Printf("empty=%t size=%d\n", lst.IsEmpty(), lst.Len())
+    for _, v := range vs { lst.PushBack(v) }
+    return lst
+}
+
+func task1_basic_ops(r *taskRun) {
+    r.section(subtask("Task1", "start"), "core list operations")
+
+    lst := newList()
+    r.section(subtask("Task1", "empty-list"), "new list is empty")
+    r.printf("empty=%t size=%d\n", lst.IsEmpty(), lst.Len())
 
-    section("push_front_back")
+    r.section(subtask("Task1", "push_front_back"), "push to both ends")
     lst.PushFront(2)
     lst.PushBack(5)
     lst.PushFront(1)
-    printList(lst, "after-push")
+    r.printList(lst, "after-push")
 
-    section("front_back")
+    r.section(subtask("Task1", "front_back"), "peek at front and back")
     f, _ := lst.Front()
     b, _ := lst.Back()
-    fmt.Printf("front=%d back=%d\n", f, b)
+    r.printf("front=%d back=%d\n", f, b)
 
-    section("pop_front")
+    r.section(subtask("Task1", "pop_front"), "pop the front element")
     ok, x := lst.PopFront()
-    fmt.Printf("ok=%t popped=%d\n", ok, x)
-    printList(lst, "after-pop")
+    r.printf("ok=%t popped=%d\n", ok, x)
+    r.printList(lst, "after-pop")
 
-    section("clear")
+    r.section(subtask("Task1", "clear"), "clear the list")
     lst.Clear()
-    fmt.Printf("empty=%t size=%d\n", lst.IsEmpty(), lst.Len())
+    r.printf("empty=%t size=%d\n", lst.IsEmpty(), lst.Len())
 
-    section("pop_last_then_push")
-    one := New()
+    r.section(subtask("Task1", "pop_last_then_push"), "pop the only element, then push")
+    one := newList()
     one.PushBack(7)
     ok2, y := one.PopFront()
-    fmt.Printf("ok=%t popped=%d\n", ok2, y)
-    fmt.Printf("empty=%t size=%d\n", one.IsEmpty(), one.Len())
+    r.printf("ok=%t popped=%d\n", ok2, y)
+    r.printf("empty=%t size=%d\n", one.IsEmpty(), one.Len())
     one.PushBack(99)
-    printList(one, "after-pop-last-then-push")
+    r.printList(one, "after-pop-last-then-push")
 }
 
-func task2_insert_erase() {
-    section("start-task2")
-    lst := New()
+func task2_insert_erase(r *taskRun) {
+    r.section(subtask("Task2", "start"), "seed five elements")
+    lst := newList()
     for i := 1; i <= 5; i++ { lst.PushBack(i) }
-    printList(lst, "seed")
+    r.printList(lst, "seed")
 
-    section("insert")
-    fmt.Printf("ok=%t\n", lst.InsertAt(0, 100))
-    fmt.Printf("ok=%t\n", lst.InsertAt(3, 200))
-    fmt.Printf("ok=%t\n", lst.InsertAt(lst.Len(), 300))
-    printList(lst, "after-insert")
+    r.section(subtask("Task2", "insert"), "insert at head, middle and end")
+    r.printf("ok=%t\n", lst.InsertAt(0, 100))
+    r.printf("ok=%t\n", lst.InsertAt(3, 200))
+    r.printf("ok=%t\n", lst.InsertAt(lst.Len(), 300))
+    r.printList(lst, "after-insert")
 
-    section("erase")
-    fmt.Printf("ok=%t\n", lst.RemoveAt(0))
-    fmt.Printf("ok=%t\n", lst.RemoveAt(2))
-    fmt.Printf("ok=%t\n", lst.RemoveAt(lst.Len()-1))
-    printList(lst, "after-erase")
+    r.section(subtask("Task2", "erase"), "erase at head, middle and end")
+    r.printf("ok=%t\n", lst.RemoveAt(0))
+    r.printf("ok=%t\n", lst.RemoveAt(2))
+    r.printf("ok=%t\n", lst.RemoveAt(lst.Len()-1))
+    r.printList(lst, "after-erase")
 
-    section("erase-tail-then-push")
+    r.section(subtask("Task2", "erase-tail-then-push"), "erase the tail, then push")
     okTail := lst.RemoveAt(lst.Len()-1)
-    fmt.Printf("ok=%t\n", okTail)
+    r.printf("ok=%t\n", okTail)
     lst.PushBack(999)
-    printList(lst, "after-erase-tail-then-push")
+    r.printList(lst, "after-erase-tail-then-push")
 }
 
-func task3_copy_move() {
-    section("start-task3")
+func task3_copy_move(r *taskRun) {
+    r.section(subtask("Task3", "start"), "build the source list")
     a := New()
     for i := 0; i < 4; i++ { a.PushBack(i*10) }
-    printList(a, "a")
+    r.printList(a, "a")
 
-    section("copy-ctor")
+    r.section(subtask("Task3", "copy-ctor"), "copy the list")
     b := a.Copy()
-    printList(b, "b")
+    r.printList(b, "b")
 
-    section("modify-original")
+    r.section(subtask("Task3", "modify-original"), "modify the original after copying")
     a.PushBack(40)
     _ = a.RemoveAt(1)
-    printList(a, "a-after")
-    printList(b, "b-unchanged")
+    r.printList(a, "a-after")
+    r.printList(b, "b-unchanged")
 
-    section("steal/move-sim")
+    r.section(subtask("Task3", "steal-move-sim"), "move into a new list")
     c := MoveFrom(a)
-    printList(c, "c")
-    printList(a, "a-moved-from")
+    r.printList(c, "c")
+    r.printList(a, "a-moved-from")
 
-    section("move-assign-sim")
+    r.section(subtask("Task3", "move-assign-sim"), "move-assign into an existing list")
     d := New()
     d.MoveAssignFrom(c)
-    printList(d, "d")
-    printList(c, "c-moved-from")
+    r.printList(d, "d")
+    r.printList(c, "c-moved-from")
 }
 
-func main() {
-    which := ""
-    if len(os.Args) >= 2 { which = os.Args[1] }
-    switch which {
-    case "task1": task1_basic_ops()
-    case "task2": task2_insert_erase()
-    case "task3": task3_copy_move()
-    default:
-        task1_basic_ops(); task2_insert_erase(); task3_copy_move()
+func task4_derived(r *taskRun) {
+    r.section(subtask("Task4", "start"), "derived lists and queries")
+
+    r.section(subtask("Task4", "copy-reversed"), "reversed copy leaves the source intact")
+    src := New()
+    for i := 1; i <= 4; i++ { src.PushBack(i) }
+    rev := src.CopyReversed()
+    r.printList(src, "original")
+    r.printList(rev, "reversed")
+    b, _ := rev.Back()
+    r.printf("reversed-back=%d\n", b)
+
+    r.section(subtask("Task4", "tee"), "two copies; changing one leaves the other")
+    copies := listOf(1, 2, 3).Tee(2)
+    copies[0].PushBack(4)
+    copies[0].ReplaceAll(1, 10)
+    r.printList(copies[0], "tee-0")
+    r.printList(copies[1], "tee-1")
+
+    r.section(subtask("Task4", "frequencies"), "frequency table in ascending value order")
+    values, counts := listOf(3, 1, 3, 2, 1, 1).Frequencies()
+    for i, v := range values { r.printf("value=%d count=%d\n", v, counts[i]) }
+
+    r.section(subtask("Task4", "scan-left"), "running product")
+    scanned := listOf(1, 2, 3, 4)
+    r.printList(scanned.ScanLeft(1, func(acc, v int) int { return acc * v }), "products")
+    r.printList(scanned, "source")
+
+    r.section(subtask("Task4", "window-max"), "sliding window maximum, k=3")
+    r.printf("maxes=%s\n", formatList(listOf(1, 3, -1, -3, 5, 3, 6, 7).WindowMax(3), r.pad))
+
+    r.section(subtask("Task4", "range-build"), "build 0..10 in steps of 2")
+    r.printList(BuildFromRange(0, 10, 2), "range")
+    r.printList(BuildFromRange(5, 0, -2), "range-down")
+
+    r.section(subtask("Task4", "capped"), "first 5 values of a 1000-element list")
+    head, truncated := BuildFromRange(0, 1000, 1).ToSliceCapped(5)
+    r.printf("head=%s truncated=%t\n", formatList(head, r.pad), truncated)
+
+    r.section(subtask("Task4", "peek-n"), "peek at the front 2 without popping")
+    peeked := listOf(1, 2, 3)
+    r.printf("peek=%s\n", formatList(peeked.PeekN(2), r.pad))
+    r.printList(peeked, "after-peek")
+
+    r.section(subtask("Task4", "as-string-slice"), "format values as hex")
+    formatted := listOf(10, 255)
+    r.printf("hex=[%s]\n", strings.Join(formatted.AsStringSlice(func(v int) string { return fmt.Sprintf("%#x", v) }), " "))
+    r.printf("default=[%s]\n", strings.Join(formatted.AsStringSlice(nil), " "))
+
+    r.section(subtask("Task4", "bucket-by"), "bucket 1..6 by value mod 3")
+    buckets := listOf(1, 2, 3, 4, 5, 6).BucketBy(func(v int) int { return v % 3 })
+    keys := make([]int, 0, len(buckets))
+    for k := range buckets { keys = append(keys, k) }
+    sort.Ints(keys)
+    for _, k := range keys { r.printList(buckets[k], fmt.Sprintf("mod%d", k)) }
+
+    r.section(subtask("Task4", "deinterleave"), "split [1 2 3 4 5] by even and odd position")
+    evenPos, oddPos := listOf(1, 2, 3, 4, 5).Deinterleave()
+    r.printList(evenPos, "even-positions")
+    r.printList(oddPos, "odd-positions")
+
+    r.section(subtask("Task4", "to-pairs"), "adjacent pairs of [1 2 3 4]")
+    r.printf("pairs=%v\n", listOf(1, 2, 3, 4).ToPairs())
+    r.printf("single=%v\n", listOf(7).ToPairs())
+
+    r.section(subtask("Task4", "exceeding"), "count and sum of values above 3")
+    exceeding := listOf(1, 4, 2, 5, 3, 6)
+    r.printf("count=%d sum=%d\n", exceeding.CountValuesExceeding(3), exceeding.SumValuesExceeding(3))
+
+    r.section(subtask("Task4", "segment-sums"), "sum [1 1 1 1 1] in segments of 2 and 3")
+    segmented := listOf(1, 1, 1, 1, 1)
+    sums, ok := segmented.SegmentSums([]int{2, 3})
+    r.printf("sums=%s ok=%t\n", formatList(sums, r.pad), ok)
+    sums, ok = segmented.SegmentSums([]int{2, 2})
+    r.printf("sums=%s ok=%t\n", formatList(sums, r.pad), ok)
+
+    r.section(subtask("Task4", "is-sorted"), "check [1 2 2 3] for ascending and descending order")
+    ordered := listOf(1, 2, 2, 3)
+    r.printf("sorted=%t sorted-desc=%t\n", ordered.IsSorted(), ordered.IsSortedDesc())
+
+    r.section(subtask("Task4", "max-gap"), "largest gap between sorted neighbours of [3 6 9 1]")
+    gapped := listOf(3, 6, 9, 1)
+    gap, ok := gapped.MaxGap()
+    r.printf("gap=%d ok=%t\n", gap, ok)
+    r.printList(gapped, "unchanged")
+    gap, ok = listOf(4).MaxGap()
+    r.printf("gap=%d ok=%t\n", gap, ok)
+
+    r.section(subtask("Task4", "summary"), "list summary as key/value pairs")
+    summary := listOf(4, 8, 15)
+    front, _ := summary.Front()
+    back, _ := summary.Back()
+    r.printKV(subtask("Task4", "summary"), map[string]string{
+        "size":  strconv.Itoa(summary.Len()),
+        "empty": strconv.FormatBool(summary.IsEmpty()),
+        "front": strconv.Itoa(front),
+        "back":  strconv.Itoa(back),
+    })
+}
+
+func task5_transforms(r *taskRun) {
+    r.section(subtask("Task5", "start"), "in-place transforms")
+
+    r.section(subtask("Task5", "replace-all"), "replace every 2 with 99")
+    lst := listOf(1, 2, 3, 2)
+    r.printf("replaced=%d\n", lst.ReplaceAll(2, 99))
+    r.printList(lst, "after-replace-all")
+
+    r.section(subtask("Task5", "replace-first"), "replace only the first 2")
+    lst = listOf(1, 2, 2, 3)
+    r.printf("ok=%t\n", lst.ReplaceFirst(2, 99))
+    r.printf("ok=%t\n", lst.ReplaceFirst(7, 99))
+    r.printList(lst, "after-replace-first")
+
+    r.section(subtask("Task5", "apply-at"), "double the value at index 2")
+    lst = listOf(1, 2, 3, 4)
+    double := func(v int) int { return v * 2 }
+    r.printf("ok=%t\n", lst.ApplyAt(2, double))
+    r.printf("ok=%t\n", lst.ApplyAt(lst.Len(), double))
+    r.printList(lst, "after-apply-at")
+
+    r.section(subtask("Task5", "clamp"), "clamp every value into [0, 10]")
+    lst = listOf(-5, 0, 5, 15)
+    lst.Clamp(0, 10)
+    r.printList(lst, "after-clamp")
+
+    r.section(subtask("Task5", "normalize"), "rescale [10 20 30] onto [0, 100]")
+    lst = listOf(10, 20, 30)
+    lst.NormalizeToRange(0, 100)
+    r.printList(lst, "after-normalize")
+
+    r.section(subtask("Task5", "unique-counting"), "collapse consecutive duplicates")
+    lst = listOf(1, 1, 1, 2, 2, 3)
+    r.printf("removed=%d\n", lst.UniqueCounting())
+    r.printList(lst, "after-unique")
+
+    r.section(subtask("Task5", "remove-adjacent-equal"), "pop equal neighbours of [1 2 3 3 2 4] until none are left")
+    lst = listOf(1, 2, 3, 3, 2, 4)
+    lst.RemoveIfAdjacentEqual()
+    r.printList(lst, "after-remove-adjacent-equal")
+    lst.PushBack(5)
+    r.printList(lst, "after-push")
+
+    r.section(subtask("Task5", "remove-where-index"), "remove every third index from 0..8")
+    lst = BuildFromRange(0, 9, 1)
+    r.printf("removed=%d\n", lst.RemoveWhereIndex(func(i int) bool { return i%3 == 2 }))
+    r.printList(lst, "after-remove-where-index")
+    lst.PushBack(9)
+    b, _ := lst.Back()
+    r.printf("back=%d\n", b)
+
+    r.section(subtask("Task5", "swap-pairs"), "swap adjacent nodes in pairs")
+    lst = listOf(1, 2, 3, 4)
+    lst.SwapPairs()
+    r.printList(lst, "even")
+    lst = listOf(1, 2, 3, 4, 5)
+    lst.SwapPairs()
+    r.printList(lst, "odd")
+    b, _ = lst.Back()
+    r.printf("back=%d\n", b)
+
+    r.section(subtask("Task5", "iqr-trim"), "drop outliers beyond 1.5 IQR of the quartiles")
+    lst = listOf(10, 12, 11, 13, 12, 100, 11)
+    lst.InterquartileTrim()
+    r.printList(lst, "after-iqr-trim")
+    b, _ = lst.Back()
+    r.printf("back=%d\n", b)
+
+    r.section(subtask("Task5", "rotate-until-sorted"), "rotate a rotated sorted list back into order")
+    lst = listOf(3, 4, 5, 1, 2)
+    rotations, ok := lst.RotateUntilSorted()
+    r.printf("rotations=%d ok=%t\n", rotations, ok)
+    r.printList(lst, "after-rotate")
+    lst.PushBack(6)
+    r.printList(lst, "after-push")
+    lst = listOf(3, 1, 2, 0)
+    rotations, ok = lst.RotateUntilSorted()
+    r.printf("rotations=%d ok=%t\n", rotations, ok)
+    r.printList(lst, "unsortable")
+
+    r.section(subtask("Task5", "insert-sorted-unique"), "insert 3, 3, 5, 1 keeping the list sorted and unique")
+    lst = New()
+    for _, v := range []int{3, 3, 5, 1} { r.printf("insert %d ok=%t\n", v, lst.InsertSortedUnique(v)) }
+    r.printList(lst, "after-insert-sorted-unique")
+
+    r.section(subtask("Task5", "dll-insert-sorted"), "insert 4, 0, 9, 5 into the ascending doubly linked [1 3 5 7]")
+    dl := NewDoubly()
+    for _, v := range []int{1, 3, 5, 7} { dl.PushBack(v) }
+    for _, v := range []int{4, 0, 9, 5} { dl.InsertSorted(v) }
+    r.printf("after-dll-insert-sorted: %s size=%d\n", formatList(dl.ToSlice(), r.pad), dl.Len())
+    r.printf("backward: %s\n", formatList(dl.ToSliceReverse(), r.pad))
+
+    r.section(subtask("Task5", "push-back-sorted"), "append 1, 3, 2 only while the list stays sorted")
+    lst = New()
+    for _, v := range []int{1, 3, 2} { r.printf("push %d err=%v\n", v, lst.PushBackSorted(v)) }
+    r.printList(lst, "after-push-back-sorted")
+
+    r.section(subtask("Task5", "remove-last"), "remove the last 2 from [1 2 3 2 4]")
+    lst = listOf(1, 2, 3, 2, 4)
+    r.printf("removed=%t\n", lst.RemoveLast(2))
+    r.printList(lst, "after-remove-last")
+
+    r.section(subtask("Task5", "max-length-front"), "keep at most 3 of [1 2 3 4 5], dropping from the front")
+    lst = listOf(1, 2, 3, 4, 5)
+    lst.EnforceMaxLength(3, true)
+    r.printList(lst, "after-drop-front")
+    lst.PushBack(6)
+    r.printList(lst, "after-push")
+
+    r.section(subtask("Task5", "max-length-back"), "keep at most 3 of [1 2 3 4 5], dropping from the back")
+    lst = listOf(1, 2, 3, 4, 5)
+    lst.EnforceMaxLength(3, false)
+    r.printList(lst, "after-drop-back")
+    lst.PushBack(6)
+    r.printList(lst, "after-push")
+}
+
+// driverTask describes one runnable task: its CLI name, the section prefix it
+// nests its labels under, and the labels it is expected to emit, in order.
+type driverTask struct {
+    name     string
+    prefix   string
+    sections []string
+    run      func(*taskRun)
+}
+
+// tasks is the registry of runnable tasks, in run order. Add tasks through
+// registerTask so their section names are checked at startup.
+var tasks []driverTask
+
+func init() {
+    registerTask(driverTask{"task1", "Task1", []string{"start", "empty-list", "push_front_back", "front_back", "pop_front", "clear", "pop_last_then_push"}, task1_basic_ops})
+    registerTask(driverTask{"task2", "Task2", []string{"start", "insert", "erase", "erase-tail-then-push"}, task2_insert_erase})
+    registerTask(driverTask{"task3", "Task3", []string{"start", "copy-ctor", "modify-original", "steal-move-sim", "move-assign-sim"}, task3_copy_move})
+    registerTask(driverTask{"task4", "Task4", []string{"start", "copy-reversed", "tee", "frequencies", "scan-left", "window-max", "range-build", "capped", "peek-n", "as-string-slice", "bucket-by", "deinterleave", "to-pairs", "exceeding", "segment-sums", "is-sorted", "max-gap", "summary"}, task4_derived})
+    registerTask(driverTask{"task5", "Task5", []string{"start", "replace-all", "replace-first", "apply-at", "clamp", "normalize", "unique-counting", "remove-adjacent-equal", "remove-where-index", "swap-pairs", "iqr-trim", "rotate-until-sorted", "insert-sorted-unique", "dll-insert-sorted", "push-back-sorted", "remove-last", "max-length-front", "max-length-back"}, task5_transforms})
+}
+
+// validSectionName matches the section labels a task may register: 1-64
+// lowercase ASCII letters, digits, '_' or '-'. Anything else (unicode, spaces,
+// delimiter characters) could confuse the output parser.
+var validSectionName = regexp.MustCompile(`^[a-z0-9_-]{1,64}$`)
+
+// registerTask adds t to the registry, panicking on an invalid section label so
+// a bad name fails the first run in CI rather than shipping in a starter.
+func registerTask(t driverTask) {
+    for _, label := range t.sections {
+        if !validSectionName.MatchString(label) {
+            panic(fmt.Sprintf("task %s: invalid section name %q (want 1-64 characters from [a-z0-9_-])", t.name, label))
+        }
     }
+    tasks = append(tasks, t)
 }
 
+// selectTasks returns the named tasks in the order given. With no names, or
+// only unknown ones, it returns every task: the grader has always run a bare
+// or mistyped name as a full run. Unknown names next to known ones are an
+// error instead, since the caller clearly meant a subset.
+func selectTasks(names []string) ([]driverTask, error) {
+    var selected []driverTask
+    var unknown []string
+    for _, name := range names {
+        found := false
+        for _, t := range tasks {
+            if t.name == name { selected = append(selected, t); found = true; break }
+        }
+        if !found { unknown = append(unknown, name) }
+    }
+    if len(selected) == 0 { return tasks, nil }
+    if len(unknown) > 0 { return nil, fmt.Errorf("unknown task %s", strings.Join(unknown, ", ")) }
+    return selected, nil
+}
+
+// runAllTasks runs every registered task in order through emit.
+func runAllTasks(emit Emitter) { runTasks(emit, tasks) }
+
+// runTasks runs the selected tasks with all section and result output sent
+// to emit, then flushes it.
+func runTasks(emit Emitter, selected []driverTask) {
+    r := &taskRun{e: emit, pad: padLists}
+    for _, t := range selected { runTask(r, t) }
+    emit.Flush()
+}
+
+// RunTask runs the named task in-process with default output settings and
+// returns what it printed, so tests can check a transcript without starting
+// the driver.
+func RunTask(name string) (string, error) {
+    for _, t := range tasks {
+        if t.name != name { continue }
+        var buf strings.Builder
+        runTasks(&outputWriter{dst: &buf, max: defaultMaxSectionBytes}, []driverTask{t})
+        return buf.String(), nil
+    }
+    return "", fmt.Errorf("unknown task %q", name)
+}
+
+// listTasks prints one line per registered task: its name followed by the
+// nested section names it emits.
+func listTasks() {
+    for _, t := range tasks { fmt.Fprintln(out.dst, t.name, strings.Join(expectedSections(t), " ")) }
+}
+
+func main() {
+    expect := flag.Bool("expect", false, "follow each result line with an \"# EXPECT\" copy (memo builds only)")
+    maxSection := flag.Int("max-section-bytes", defaultMaxSectionBytes, "cap on output bytes per section before it is truncated (0 disables)")
+    pad := flag.Bool("pad", false, "right-align list values to the widest value in each printed list")
+    headers := flag.String("headers", "v1", "section header format: v1 (name only) or v2 (id and title)")
+    format := flag.String("format", "text", "output format: text, json (one array of sections) or jsonl (one section object per line)")
+    list := flag.Bool("list-tasks", false, "list the registered tasks and their section names, then exit")
+    validate := flag.Bool("validate-sections", false, "run the selected tasks silently and check the sections they emit against the schema")
+    script := flag.Bool("script", false, "run driver commands read from stdin instead of the tasks (see script.go)")
+    cover := flag.String("coverprofile", "", "write a coverage profile of the run to this file (binaries built with go build -cover -covermode=atomic only)")
+    flag.Parse()
+    if *cover != "" && !coverBuild() {
+        fmt.Fprintln(os.Stderr, "-coverprofile needs a binary built with go build -cover -covermode=atomic")
+        exit(64)
+    }
+    if *cover != "" {
+        coverProfile = *cover
+        watchCoverSignals()
+        // write the profile on return and on a panic (an unimplemented
+        // method), then let the panic continue
+        defer func() {
+            r := recover()
+            writeCoverProfile()
+            if r != nil { panic(r) }
+        }()
+    }
+    if *expect && !memoBuild {
+        fmt.Fprintln(os.Stderr, "-expect is only available in memo builds (go build -tags memo)")
+        exit(64)
+    }
+    if *headers != "v1" && *headers != "v2" {
+        fmt.Fprintf(os.Stderr, "unknown -headers format %q (want v1 or v2)\n", *headers)
+        exit(64)
+    }
+    if *format != "text" && *format != "json" && *format != "jsonl" {
+        fmt.Fprintf(os.Stderr, "unknown -format %q (want text, json or jsonl)\n", *format)
+        exit(64)
+    }
+    if *expect && *format != "text" {
+        fmt.Fprintln(os.Stderr, "-expect only applies to -format=text")
+        exit(64)
+    }
+    if (*list || *validate) && *format != "text" {
+        fmt.Fprintln(os.Stderr, "-list-tasks and -validate-sections print text reports; drop -format")
+        exit(64)
+    }
+    selected, err := selectTasks(flag.Args())
+    if err != nil {
+        fmt.Fprintln(os.Stderr, err)
+        exit(64)
+    }
+    out.v2 = *headers == "v2"
+    if *list {
+        listTasks()
+        return
+    }
+    if *validate {
+        if !validateSections(selected) { exit(1) }
+        return
+    }
+    out.expect, out.max = *expect, *maxSection
+    padLists = *pad
+    var emit Emitter = out
+    if *format != "text" { emit = &structuredEmitter{dst: out.dst, jsonl: *format == "jsonl", max: *maxSection} }
+    if *script {
+        _, failed := runScript(&taskRun{e: emit, pad: padLists}, os.Stdin)
+        emit.Flush()
+        if failed > 0 { exit(1) }
+        return
+    }
+    if flag.NArg() == 0 {
+        runAllTasks(emit)
+        return
+    }
+    runTasks(emit, selected)
+}
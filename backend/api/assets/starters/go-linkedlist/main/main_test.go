@@ -0,0 +1,603 @@
+package main
+
+// Like the grader, these tests run with main.go next to a linked_list.go:
+// ./test.sh in the starter root stages that layout against the memo (or copy
+// memo/linked_list.go into the build directory and run `make test`). It
+// then reruns them with memo/alt in place of linked_list.go, once per
+// alternative's tag.
+
+import (
+    "bytes"
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "reflect"
+    "regexp"
+    "runtime/debug"
+    "strconv"
+    "strings"
+    "testing"
+)
+
+// altTag is the memo/alt tag this test binary was built with, or "". Next
+// to memo/alt nothing else declares LinkedList, so every binary the tests
+// build needs the tag too (see withAlt).
+var altTag = func() string {
+    info, ok := debug.ReadBuildInfo()
+    if !ok { return "" }
+    for _, s := range info.Settings {
+        if s.Key != "-tags" { continue }
+        for _, tag := range strings.Split(s.Value, ",") {
+            for _, alt := range altImpls {
+                if tag == alt.tag { return tag }
+            }
+        }
+    }
+    return ""
+}()
+
+// withAlt adds altTag to the comma-separated build tags.
+func withAlt(tags string) string {
+    if altTag == "" { return tags }
+    if tags == "" { return altTag }
+    return tags + "," + altTag
+}
+
+func buildDriver(t *testing.T, tags string) string {
+    t.Helper()
+    bin := filepath.Join(t.TempDir(), "app")
+    cmd := exec.Command("go", "build", "-tags", withAlt(tags), "-o", bin, ".")
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil {
+        t.Fatalf("go build -tags %q: %v\n%s", tags, err, msg)
+    }
+    return bin
+}
+
+func runDriver(t *testing.T, bin string, args ...string) (string, string, int) {
+    t.Helper()
+    var stdout, stderr bytes.Buffer
+    cmd := exec.Command(bin, args...)
+    cmd.Stdout, cmd.Stderr = &stdout, &stderr
+    code := 0
+    if err := cmd.Run(); err != nil {
+        var exit *exec.ExitError
+        if !errors.As(err, &exit) { t.Fatalf("run %v: %v", args, err) }
+        code = exit.ExitCode()
+    }
+    return stdout.String(), stderr.String(), code
+}
+
+// stripExpect removes "# EXPECT" annotations the way the output parser would.
+func stripExpect(s string) string {
+    var b strings.Builder
+    for _, line := range strings.SplitAfter(s, "\n") {
+        if strings.HasPrefix(line, expectPrefix) { continue }
+        b.WriteString(line)
+    }
+    return b.String()
+}
+
+func TestOutputWriterExpect(t *testing.T) {
+    var buf bytes.Buffer
+    w := &outputWriter{dst: &buf, expect: true}
+    w.header("Task1Start", "")
+    w.Write([]byte("a: [1"))
+    w.Write([]byte(" 2] size=2\nok=true\n"))
+    w.header("Task1Next", "")
+    w.Write([]byte("tail"))
+    w.Flush()
+
+    want := "### Task1Start\n" +
+        "a: [1 2] size=2\n# EXPECT a: [1 2] size=2\n" +
+        "ok=true\n# EXPECT ok=true\n" +
+        "### Task1Next\n" +
+        "tail\n# EXPECT tail\n"
+    if buf.String() != want { t.Fatalf("got:\n%s\nwant:\n%s", buf.String(), want) }
+}
+
+func TestExpectRejectedOutsideMemoBuild(t *testing.T) {
+    bin := buildDriver(t, "")
+    stdout, stderr, code := runDriver(t, bin, "-expect", "task1")
+    if code != 64 { t.Fatalf("exit code = %d, want 64", code) }
+    if stdout != "" { t.Fatalf("unexpected stdout: %q", stdout) }
+    if !strings.Contains(stderr, "memo") { t.Fatalf("stderr should explain the memo restriction: %q", stderr) }
+}
+
+func TestExpectRoundTrip(t *testing.T) {
+    bin := buildDriver(t, "memo")
+    for _, task := range []string{"task1", "task2", "task3", ""} {
+        args := []string{}
+        if task != "" { args = append(args, task) }
+        plain, _, code := runDriver(t, bin, args...)
+        if code != 0 { t.Fatalf("%q: exit code %d", task, code) }
+        annotated, _, code := runDriver(t, bin, append([]string{"-expect"}, args...)...)
+        if code != 0 { t.Fatalf("%q -expect: exit code %d", task, code) }
+
+        lines := strings.Split(strings.TrimSuffix(annotated, "\n"), "\n")
+        for i, line := range lines {
+            if strings.HasPrefix(line, DELIM) || strings.HasPrefix(line, expectPrefix) { continue }
+            if i+1 >= len(lines) || lines[i+1] != expectPrefix+line {
+                t.Fatalf("%q: result line %d %q is not followed by its EXPECT copy", task, i, line)
+            }
+        }
+        if got := stripExpect(annotated); got != plain {
+            t.Fatalf("%q: stripped output differs from plain output:\n%s\n---\n%s", task, got, plain)
+        }
+    }
+}
+
+func TestValidateSectionsEmitted(t *testing.T) {
+    expected := []string{"Task1Start", "Task1Clear", "Task1PopFront"}
+    if err := ValidateSectionsEmitted([]string{"Task1Start", "Task1Clear", "Task1PopFront"}, expected); err != nil {
+        t.Fatalf("matching sections: %v", err)
+    }
+    err := ValidateSectionsEmitted([]string{"Task1Start", "Task1Extra", "Task1PopFront"}, expected)
+    if err == nil { t.Fatal("expected an error for missing and extra sections") }
+    for _, want := range []string{"missing sections: Task1Clear", "extra sections: Task1Extra"} {
+        if !strings.Contains(err.Error(), want) { t.Fatalf("error %q does not mention %q", err, want) }
+    }
+}
+
+func TestTasksEmitTheirSchema(t *testing.T) {
+    var report bytes.Buffer
+    saved := out.dst
+    out.dst = &report
+    defer func() { out.dst = saved }()
+    if !validateSections(tasks) { t.Fatalf("section validation failed:\n%s", report.String()) }
+}
+
+func TestOutputWriterSectionCap(t *testing.T) {
+    var buf bytes.Buffer
+    w := &outputWriter{dst: &buf, max: 64 << 10}
+    w.header("Task9Flood", "")
+    line := strings.Repeat("x", 99) + "\n"
+    for i := 0; i < 20000; i++ { w.Write([]byte(line)) } // ~2MB
+    w.header("Task9Runaway", "")
+    for i := 0; i < 1000; i++ { w.Write([]byte(strings.Repeat("y", 4096))) } // one unterminated ~4MB line
+    w.header("Task9After", "")
+    w.Write([]byte("ok=true\n"))
+    w.Flush()
+
+    sections := strings.Split(buf.String(), DELIM+" ")
+    if len(sections) != 4 { t.Fatalf("got %d sections, want 3", len(sections)-1) }
+
+    flood := strings.TrimPrefix(sections[1], "Task9Flood\n")
+    body := flood[:strings.Index(flood, noticePrefix)]
+    if len(body) > w.max { t.Fatalf("flood section kept %d bytes, cap is %d", len(body), w.max) }
+    if want := fmt.Sprintf("%s truncated Task9Flood bytes=%d\n", noticePrefix, len(body)); !strings.HasSuffix(flood, want) {
+        t.Fatalf("flood section should end with %q, got tail %q", want, flood[len(flood)-80:])
+    }
+    if strings.Count(flood, noticePrefix) != 1 { t.Fatal("truncation marker should appear exactly once") }
+
+    if want := "Task9Runaway\n" + noticePrefix + " truncated Task9Runaway bytes=0\n"; sections[2] != want {
+        t.Fatalf("runaway section = %q, want %q", sections[2], want)
+    }
+    if sections[3] != "Task9After\nok=true\n" { t.Fatalf("section after truncation not intact: %q", sections[3]) }
+}
+
+// TestFloodingTaskIsCapped runs a stub task that prints megabytes into one
+// section through the task runner, in both header formats: the section must
+// stop at the cap with one truncation marker, the next section must be
+// intact and the transcript must still follow the section grammar.
+func TestFloodingTaskIsCapped(t *testing.T) {
+    saved := tasks
+    defer func() { tasks = saved }()
+    registerTask(driverTask{"task9", "Task9", []string{"flood", "after"}, func(r *taskRun) {
+        r.section(subtask("Task9", "flood"), "prints far too much")
+        for i := 0; i < 1<<15; i++ { r.printf("%s\n", strings.Repeat("x", 99)) } // ~3MB
+        r.section(subtask("Task9", "after"), "prints normally")
+        r.printf("ok=true\n")
+    }})
+    stub := tasks[len(tasks)-1:]
+    for _, v2 := range []bool{false, true} {
+        var buf bytes.Buffer
+        runTasks(&outputWriter{dst: &buf, max: defaultMaxSectionBytes, v2: v2}, stub)
+        got := buf.String()
+        if len(got) > defaultMaxSectionBytes+200 { t.Fatalf("v2=%t: transcript is %d bytes, cap is %d per section", v2, len(got), defaultMaxSectionBytes) }
+        if n := strings.Count(got, noticePrefix+" truncated Task9Flood bytes="); n != 1 { t.Fatalf("v2=%t: %d truncation markers, want 1", v2, n) }
+        after := DELIM + " Task9After\nok=true\n"
+        if v2 { after = markerDelim + ` id=Task9After title="prints normally"` + "\nok=true\n" }
+        if !strings.HasSuffix(got, after) { t.Fatalf("v2=%t: section after truncation not intact, tail %q", v2, got[len(got)-120:]) }
+        if err := validateTranscript("./main task9\n"+got, v2); err != nil { t.Fatalf("v2=%t: %v", v2, err) }
+    }
+}
+
+func TestFormatListPadded(t *testing.T) {
+    var span []int
+    for v := -100; v <= 1000; v++ { span = append(span, v) }
+    cases := []struct {
+        golden string
+        vs     []int
+    }{
+        {"range", span},
+        {"mixed", []int{1000, -100, 7, 0, -5, 42}},
+        {"single", []int{-3}},
+        {"empty", nil},
+    }
+    for _, c := range cases {
+        want, err := os.ReadFile(filepath.Join("testdata", "pad", c.golden+".golden"))
+        if err != nil { t.Fatal(err) }
+        if got := formatList(c.vs, true) + "\n"; got != string(want) {
+            t.Errorf("%s: padded rendering differs from golden:\ngot:  %q\nwant: %q", c.golden, got, want)
+        }
+    }
+}
+
+func TestFormatListUnpaddedByDefault(t *testing.T) {
+    if got := formatList([]int{1000, -100, 7}, false); got != "[1000 -100 7]" {
+        t.Fatalf("unpadded rendering changed: %q", got)
+    }
+    if got := formatList(nil, false); got != "[]" { t.Fatalf("empty rendering changed: %q", got) }
+}
+
+// captureAll runs every task in-process with the given header format and returns the transcript.
+func captureAll(t *testing.T, v2 bool) string {
+    t.Helper()
+    var buf bytes.Buffer
+    runAllTasks(&outputWriter{dst: &buf, v2: v2})
+    return buf.String()
+}
+
+// recordingEmitter is a minimal Emitter that keeps the header names and the
+// printed text apart.
+type recordingEmitter struct {
+    headers []string
+    text    bytes.Buffer
+    flushes int
+}
+
+func (r *recordingEmitter) Write(p []byte) (int, error) { return r.text.Write(p) }
+func (r *recordingEmitter) header(name, title string) { r.headers = append(r.headers, name) }
+func (r *recordingEmitter) notice(text string)        {}
+func (r *recordingEmitter) list(string, []int)        {}
+func (r *recordingEmitter) Flush() { r.flushes++ }
+
+func TestRunAllTasksThroughEmitter(t *testing.T) {
+    var r recordingEmitter
+    runAllTasks(&r)
+    var want []string
+    for _, task := range tasks { want = append(want, expectedSections(task)...) }
+    if strings.Join(r.headers, " ") != strings.Join(want, " ") { t.Fatalf("headers:\n%v\nwant:\n%v", r.headers, want) }
+    if !strings.Contains(r.text.String(), "after-push: [1 2 5] size=3\n") { t.Fatalf("task output not captured:\n%s", r.text.String()) }
+    if r.flushes != 1 { t.Fatalf("Flush called %d times, want 1", r.flushes) }
+}
+
+func TestV2HeaderGrammar(t *testing.T) {
+    header := regexp.MustCompile(`^&-=-& id=(Task\d+[A-Za-z0-9]+) title="[^"\\]+"$`)
+    seen := map[string]bool{}
+    for _, line := range strings.Split(captureAll(t, true), "\n") {
+        if strings.HasPrefix(line, DELIM) { t.Fatalf("v1 header in v2 output: %q", line) }
+        if !strings.HasPrefix(line, markerDelim) { continue }
+        m := header.FindStringSubmatch(line)
+        if m == nil { t.Fatalf("malformed v2 header: %q", line) }
+        if seen[m[1]] { t.Fatalf("duplicate section id %s", m[1]) }
+        seen[m[1]] = true
+    }
+    if len(seen) == 0 { t.Fatal("no v2 headers emitted") }
+}
+
+func TestV1HeaderIsLegacyLabel(t *testing.T) {
+    header := regexp.MustCompile(`^### Task\d+[A-Za-z0-9]+$`)
+    for _, line := range strings.Split(captureAll(t, false), "\n") {
+        if strings.Contains(line, markerDelim) || strings.Contains(line, "title=") { t.Fatalf("v2 syntax in v1 output: %q", line) }
+        if strings.HasPrefix(line, DELIM) && !header.MatchString(line) { t.Fatalf("v1 header is not a bare label: %q", line) }
+    }
+}
+
+func TestRegisteredSectionNamesValid(t *testing.T) {
+    for _, task := range tasks {
+        for _, label := range task.sections {
+            if !validSectionName.MatchString(label) { t.Errorf("%s: invalid section name %q", task.name, label) }
+        }
+    }
+}
+
+func TestValidSectionName(t *testing.T) {
+    cases := []struct {
+        label string
+        ok    bool
+    }{
+        {"push_front_back", true},
+        {"erase-tail-then-push", true},
+        {"start", true},
+        {"r2d2", true},
+        {strings.Repeat("a", 64), true},
+        {strings.Repeat("a", 65), false},
+        {"", false},
+        {"Push", false},
+        {"push front", false},
+        {" start", false},
+        {"start\n", false},
+        {"café", false},
+        {"ｓｔａｒｔ", false},
+        {"steal/move-sim", false},
+        {"###", false},
+        {"a### b", false},
+        {"&-=-&", false},
+        {"x&-=-&truncated", false},
+    }
+    for _, c := range cases {
+        if got := validSectionName.MatchString(c.label); got != c.ok {
+            t.Errorf("validSectionName(%q) = %t, want %t", c.label, got, c.ok)
+        }
+    }
+}
+
+func TestRegisterTaskRejectsInvalidName(t *testing.T) {
+    saved := tasks
+    defer func() { tasks = saved }()
+    defer func() {
+        msg, _ := recover().(string)
+        if !strings.Contains(msg, `"bad name"`) { t.Fatalf("expected a panic naming the bad section, got %q", msg) }
+    }()
+    registerTask(driverTask{"task9", "Task9", []string{"start", "bad name"}, func(*taskRun) {}})
+}
+
+// capture runs fn against a fresh writer and returns what it printed.
+func capture(fn func(r *taskRun)) string {
+    var buf bytes.Buffer
+    w := &outputWriter{dst: &buf}
+    fn(&taskRun{e: w})
+    w.Flush()
+    return buf.String()
+}
+
+func TestPrintKV(t *testing.T) {
+    got := capture(func(r *taskRun) { r.section("Task9KV"); r.printKV("Task9KV", map[string]string{"b": "2", "a": "1", "c": "x=y", "d": "two\nlines", "e": `back\slash`}) })
+    want := "### Task9KV\na=1\nb=2\nc=x\\=y\nd=two\\nlines\ne=back\\\\slash\n"
+    if got != want { t.Fatalf("got:\n%q\nwant:\n%q", got, want) }
+
+    if got := capture(func(r *taskRun) { r.section("Task9Empty"); r.printKV("Task9Empty", map[string]string{}) }); got != "### Task9Empty\n" {
+        t.Fatalf("empty map: got %q", got)
+    }
+}
+
+func TestPrintKVOverflow(t *testing.T) {
+    pairs := map[string]string{}
+    for i := 0; i < maxKVPairs+7; i++ { pairs[fmt.Sprintf("k%03d", i)] = strconv.Itoa(i) }
+    lines := strings.Split(strings.TrimSuffix(capture(func(r *taskRun) { r.section("Task9Big"); r.printKV("Task9Big", pairs) }), "\n"), "\n")
+    if len(lines) != 1+maxKVPairs+1 { t.Fatalf("got %d lines, want header + %d pairs + marker", len(lines), maxKVPairs) }
+    if lines[maxKVPairs] != fmt.Sprintf("k%03d=%d", maxKVPairs-1, maxKVPairs-1) { t.Fatalf("last kept pair = %q", lines[maxKVPairs]) }
+    if want := noticePrefix + " overflow Task9Big omitted=7"; lines[len(lines)-1] != want {
+        t.Fatalf("overflow marker = %q, want %q", lines[len(lines)-1], want)
+    }
+
+    // the marker is not expected output, so -expect must not echo it
+    var buf bytes.Buffer
+    w := &outputWriter{dst: &buf, expect: true}
+    (&taskRun{e: w}).printKV("Task9Big", pairs)
+    w.Flush()
+    if strings.Contains(buf.String(), expectPrefix+noticePrefix) || !strings.HasSuffix(buf.String(), "\n"+noticePrefix+" overflow Task9Big omitted=7\n") {
+        t.Fatalf("overflow marker echoed as EXPECT or missing:\n%s", buf.String()[strings.LastIndex(buf.String(), "k049"):])
+    }
+}
+
+func TestEqualJSON(t *testing.T) {
+    cases := []struct {
+        a, b string
+        eq   bool
+        msg  string
+    }{
+        {`[1,2,3]`, `[1, 2, 3]`, true, ""},
+        {`[]`, ` [ ] `, true, ""},
+        {`[1,2,3]`, `[1,2]`, false, "length: a has 3 values, b has 2"},
+        {`[1,2]`, `[1,2,3]`, false, "length: a has 2 values, b has 3"},
+        {`[1,5,3]`, `[1,2,3]`, false, "index 1: a has 5, b has 2"},
+        {`[1,2`, `[1,2]`, false, "malformed JSON in a"},
+        {`[1,2]`, `{"x":1}`, false, "malformed JSON in b"},
+        {`[1,2]`, `[1,"2"]`, false, "malformed JSON in b"},
+        {`[1.5]`, `[1]`, false, "malformed JSON in a"},
+    }
+    for _, c := range cases {
+        eq, msg := EqualJSON([]byte(c.a), []byte(c.b))
+        if eq != c.eq || !strings.HasPrefix(msg, c.msg) || (c.eq && msg != "") {
+            t.Errorf("EqualJSON(%s, %s) = (%t, %q), want (%t, %q...)", c.a, c.b, eq, msg, c.eq, c.msg)
+        }
+    }
+}
+
+func TestEqualModuloWhitespace(t *testing.T) {
+    cases := []struct {
+        a, b string
+        eq   bool
+    }{
+        {"a\nb\n", "a\nb\n", true},
+        {"a  \nb\t\n", "a\nb\n", true},
+        {"a\r\nb\r\n", "a\nb\n", true},
+        {"a\nb", "a\nb\n", true},
+        {"a\nb \n", "a\nb", true},
+        {"", "\n", true},
+        {"a\nb\n", "a\nc\n", false},
+        {"a b\n", "ab\n", false},
+        {" a\n", "a\n", false},
+        {"a\n\n", "a\n", false},
+        {"a\nb\n", "a\n", false},
+    }
+    for _, c := range cases {
+        if eq := EqualModuloWhitespace(c.a, c.b); eq != c.eq { t.Errorf("EqualModuloWhitespace(%q, %q) = %t, want %t", c.a, c.b, eq, c.eq) }
+        if eq := EqualModuloWhitespace(c.b, c.a); eq != c.eq { t.Errorf("EqualModuloWhitespace(%q, %q) = %t, want %t", c.b, c.a, eq, c.eq) }
+    }
+}
+
+func TestCompareSections(t *testing.T) {
+    memo := "go run .\n### Task1Start\n[1 2] size=2\n\n### Task1Clear\n[] size=0\n"
+    cases := []struct {
+        name    string
+        student string
+        want    map[string]bool
+    }{
+        {"identical", memo, map[string]bool{"Task1Start": true, "Task1Clear": true}},
+        {"trailing blank lines", strings.Replace(memo, "size=0\n", "size=0\n\n\n", 1), map[string]bool{"Task1Start": true, "Task1Clear": true}},
+        {"missing section", "### Task1Start\n[1 2] size=2\n", map[string]bool{"Task1Start": true, "Task1Clear": false}},
+        {"extra section", memo + "### Task1Debug\nhello\n", map[string]bool{"Task1Start": true, "Task1Clear": true}},
+        {"content mismatch", strings.Replace(memo, "[1 2]", "[2 1]", 1), map[string]bool{"Task1Start": false, "Task1Clear": true}},
+        {"trailing space", strings.Replace(memo, "size=2", "size=2 ", 1), map[string]bool{"Task1Start": false, "Task1Clear": true}},
+        {"no output", "", map[string]bool{"Task1Start": false, "Task1Clear": false}},
+    }
+    for _, c := range cases {
+        got, err := CompareSections(memo, c.student)
+        if err != nil || !reflect.DeepEqual(got, c.want) { t.Errorf("%s: CompareSections = (%v, %v), want %v", c.name, got, err, c.want) }
+    }
+    for _, bad := range []string{"", "no delimiter\n", "### A\nx\n### A\ny\n"} {
+        if _, err := CompareSections(bad, memo); err == nil { t.Errorf("CompareSections(%q, ...) accepted an unpairable memo", bad) }
+    }
+}
+
+// TestCompareSectionsRejectsRepeatedSection runs a task that emits section
+// "x" twice. Paired by name, its second x would be compared against the
+// memo's first, so the comparison must fail with an error naming x whichever
+// side printed it.
+func TestCompareSectionsRejectsRepeatedSection(t *testing.T) {
+    good := runFake(func(r *taskRun) {
+        r.section("x")
+        r.printf("first\n")
+        r.section("y")
+        r.printf("second\n")
+    })
+    twice := runFake(func(r *taskRun) {
+        r.section("x")
+        r.printf("first\n")
+        r.section("x")
+        r.printf("second\n")
+    })
+    for _, c := range []struct{ memo, student, want string }{
+        {good, twice, `student output repeats section "x"`},
+        {twice, good, `memo output repeats section "x"`},
+    } {
+        res, err := CompareSections(c.memo, c.student)
+        if err == nil || err.Error() != c.want { t.Errorf("CompareSections = (%v, %v), want error %q", res, err, c.want) }
+    }
+}
+
+// TestCompareSectionsGolden compares each golden transcript with itself and
+// with a copy whose last line is changed: only that line's section fails.
+func TestCompareSectionsGolden(t *testing.T) {
+    for _, task := range tasks {
+        memo := goldenText(t, task.name)
+        same, err := CompareSections(memo, memo)
+        if err != nil { t.Fatalf("%s: %v", task.name, err) }
+        secs := splitSections(memo)
+        if len(same) != len(secs) { t.Fatalf("%s: %d results for %d sections", task.name, len(same), len(secs)) }
+        last := secs[len(secs)-1]
+        if len(last.Lines) == 0 { continue }
+        broken := strings.TrimSuffix(memo, "\n") + "!\n"
+        res, err := CompareSections(memo, broken)
+        if err != nil { t.Fatalf("%s: %v", task.name, err) }
+        for name, ok := range res {
+            if ok == (name == last.ID) { t.Errorf("%s: section %s passed=%t after changing the last line of %s", task.name, name, ok, last.ID) }
+        }
+    }
+}
+
+var update = flag.Bool("update", false, "rewrite the golden transcripts under testdata/golden and the snapshots under testdata/json")
+
+// TestGoldenTranscripts pins the exact memo transcript of every task and of the
+// default run-everything mode; the allocator schema and stored memo outputs
+// are derived from it, so any change must be deliberate (go test -update).
+func TestGoldenTranscripts(t *testing.T) {
+    bin := buildDriver(t, "")
+    runs := []string{"all"}
+    for _, task := range tasks { runs = append(runs, task.name) }
+    for _, name := range runs {
+        args := []string{name}
+        if name == "all" { args = nil }
+        stdout, stderr, code := runDriver(t, bin, args...)
+        if code != 0 { t.Fatalf("%s: exit code %d\n%s", name, code, stderr) }
+        path := filepath.Join("testdata", "golden", name+".txt")
+        if *update {
+            if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { t.Fatal(err) }
+            if err := os.WriteFile(path, []byte(stdout), 0o644); err != nil { t.Fatal(err) }
+            continue
+        }
+        want, err := os.ReadFile(path)
+        if err != nil { t.Fatalf("%s: %v (run go test -update to create it)", name, err) }
+        if stdout != string(want) { t.Errorf("%s: transcript differs from %s (run go test -update if intended)\ngot:\n%s", name, path, stdout) }
+    }
+}
+
+// TestRunTaskGolden checks the in-process transcripts against the same golden
+// files the subprocess test uses.
+func TestRunTaskGolden(t *testing.T) {
+    for _, name := range []string{"task1", "task2", "task3"} {
+        got, err := RunTask(name)
+        if err != nil { t.Fatal(err) }
+        want, err := os.ReadFile(filepath.Join("testdata", "golden", name+".txt"))
+        if err != nil { t.Fatal(err) }
+        if got != string(want) { t.Errorf("RunTask(%q) differs from its golden transcript\ngot:\n%s", name, got) }
+    }
+    if _, err := RunTask("no-such-task"); err == nil || !strings.Contains(err.Error(), `"no-such-task"`) {
+        t.Fatalf("RunTask on an unknown name: err = %v", err)
+    }
+}
+
+func TestSecretTasksNeedTag(t *testing.T) {
+    if _, err := os.Stat("task_secret_tail.go"); err != nil { t.Skip("secret/task_secret_tail.go is not staged next to the driver (run ./test.sh)") }
+    plain, secret := buildDriver(t, ""), buildDriver(t, "secret")
+
+    listed, _, _ := runDriver(t, plain, "-list-tasks")
+    if strings.Contains(strings.ToLower(listed), "secret") { t.Fatalf("default build lists a secret task:\n%s", listed) }
+    listedSecret, _, _ := runDriver(t, secret, "-list-tasks")
+    if !strings.HasPrefix(listedSecret, listed) { t.Fatalf("secret build changed the public tasks:\n%s", listedSecret) }
+    want := "secret1 Secret1Start Secret1DrainThenPush Secret1RemoveLastThenPush Secret1InsertEndThenPush Secret1MoveThenPush\n"
+    if extra := strings.TrimPrefix(listedSecret, listed); extra != want { t.Fatalf("secret build adds %q, want %q", extra, want) }
+
+    all, _, _ := runDriver(t, plain)
+    if got, _, _ := runDriver(t, plain, "secret1"); got != all { t.Fatalf("default build ran something other than the public tasks for secret1:\n%s", got) }
+    got, stderr, code := runDriver(t, secret, "secret1")
+    if code != 0 || !strings.HasPrefix(got, DELIM+" Secret1Start\n") { t.Fatalf("secret1 in the secret build: exit %d\n%s%s", code, got, stderr) }
+    if report, _, code := runDriver(t, secret, "-validate-sections", "secret1"); code != 0 { t.Fatalf("secret1 schema: %s", report) }
+}
+
+func TestCoverProfile(t *testing.T) {
+    if altTag != "" { t.Skipf("-tags %s builds memo/alt's list, whose lines the test does not know", altTag) }
+    bin := filepath.Join(t.TempDir(), "app")
+    cmd := exec.Command("go", "build", "-cover", "-covermode=atomic", "-o", bin, ".")
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("go build -cover -covermode=atomic: %v\n%s", err, msg) }
+
+    // PushFront's first block starts on the line after its signature
+    src, err := os.ReadFile("linked_list.go")
+    if err != nil { t.Fatal(err) }
+    line := 0
+    for i, l := range strings.Split(string(src), "\n") {
+        if strings.HasPrefix(l, "func (l *LinkedList) PushFront(") { line = i + 2 }
+    }
+    if line == 0 { t.Fatal("PushFront not found in linked_list.go") }
+
+    profile := filepath.Join(t.TempDir(), "out.cov")
+    if _, stderr, code := runDriver(t, bin, "-coverprofile="+profile, "task1"); code != 0 { t.Fatalf("exit code %d\n%s", code, stderr) }
+    data, err := os.ReadFile(profile)
+    if err != nil { t.Fatal(err) }
+    // profile lines look like "<dir>/linked_list.go:43.33,48.2 4 1"
+    covered := regexp.MustCompile(`linked_list\.go:` + strconv.Itoa(line) + `\.\d+,\d+\.\d+ \d+ [1-9]\d*\n`)
+    if !covered.Match(data) { t.Fatalf("profile does not show PushFront (line %d) as run:\n%s", line, data) }
+}
+
+// TestParallelSafety runs every task several times at once, each run with its
+// own writer, and checks each transcript against the sequential golden file.
+// A mismatch (or a report under ./test.sh's -race run) means tasks or the
+// list share package-level mutable state.
+func TestParallelSafety(t *testing.T) {
+    const copies = 4
+    type result struct{ name, got string }
+    results := make(chan result, copies*len(tasks))
+    for i := 0; i < copies; i++ {
+        for _, task := range tasks {
+            go func(name string) {
+                got, err := RunTask(name)
+                if err != nil { got = err.Error() }
+                results <- result{name, got}
+            }(task.name)
+        }
+    }
+    for i := 0; i < copies*len(tasks); i++ {
+        r := <-results
+        want, err := os.ReadFile(filepath.Join("testdata", "golden", r.name+".txt"))
+        if err != nil { t.Fatal(err) }
+        if r.got != string(want) { t.Errorf("%s run concurrently differs from its golden transcript\ngot:\n%s", r.name, r.got) }
+    }
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+    "os"
+    "os/exec"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+type fakeTodo string
+
+func (f fakeTodo) NotImplemented() string { return string(f) }
+
+func runFake(run func(*taskRun)) string {
+    var buf strings.Builder
+    runTasks(&outputWriter{dst: &buf, max: defaultMaxSectionBytes}, []driverTask{{"taskx", "TaskX", []string{"start", "a", "b"}, run}})
+    return buf.String()
+}
+
+func TestRunTaskNotImplemented(t *testing.T) {
+    got := runFake(func(r *taskRun) {
+        r.section(subtask("TaskX", "start"))
+        r.printf("ok\n")
+        r.section(subtask("TaskX", "a"))
+        panic(fakeTodo("Thing"))
+    })
+    want := "### TaskXStart\nok\n### TaskXA\nNOT IMPLEMENTED: Thing\n### TaskXB\nNOT RUN: Thing is not implemented\n"
+    if got != want { t.Errorf("got:\n%s\nwant:\n%s", got, want) }
+
+    got = runFake(func(r *taskRun) { panic(fakeTodo("New")) })
+    want = "### TaskXStart\nNOT IMPLEMENTED: New\n### TaskXA\nNOT RUN: New is not implemented\n### TaskXB\nNOT RUN: New is not implemented\n"
+    if got != want { t.Errorf("panic before the first section:\ngot:\n%s\nwant:\n%s", got, want) }
+}
+
+func TestRunTaskOtherPanicsPropagate(t *testing.T) {
+    defer func() {
+        if v := recover(); v != "boom" { t.Fatalf("recovered %v, want the task's own panic", v) }
+    }()
+    runFake(func(r *taskRun) { r.section(subtask("TaskX", "start")); panic("boom") })
+    t.Fatal("panic was swallowed")
+}
+
+// TestSpecSkeletonEmitsEverySection builds the driver against the untouched
+// spec skeleton, as a student's first submission would be, and checks that it
+// exits cleanly and prints every section of every task.
+func TestSpecSkeletonEmitsEverySection(t *testing.T) {
+    self, err := filepath.EvalSymlinks("main.go")
+    if err != nil { t.Fatal(err) }
+    spec, err := filepath.Abs(filepath.Join(filepath.Dir(self), "..", "spec", "linked_list.go"))
+    if err != nil { t.Fatal(err) }
+    if _, err := os.Stat(spec); err != nil { t.Skip("spec skeleton not found next to main/; run through ./test.sh") }
+    dir := t.TempDir()
+    srcs, _ := filepath.Glob("*.go")
+    for _, src := range append(srcs, spec) {
+        if strings.HasSuffix(src, "_test.go") || src == "linked_list.go" { continue }
+        abs, _ := filepath.Abs(src)
+        if err := os.Symlink(abs, filepath.Join(dir, filepath.Base(src))); err != nil { t.Fatal(err) }
+    }
+    bin := filepath.Join(dir, "app")
+    cmd := exec.Command("go", "build", "-o", bin, ".")
+    cmd.Dir, cmd.Env = dir, append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("go build against the spec: %v\n%s", err, msg) }
+
+    stdout, stderr, code := runDriver(t, bin)
+    if code != 0 || stderr != "" { t.Fatalf("exit code %d\nstderr:\n%s", code, stderr) }
+    var want, got []string
+    for _, task := range tasks { want = append(want, expectedSections(task)...) }
+    for _, sec := range splitSections(stdout) { got = append(got, sec.ID) }
+    if !reflect.DeepEqual(got, want) { t.Fatalf("sections %v\nwant %v", got, want) }
+    if !strings.Contains(stdout, "NOT IMPLEMENTED: ") { t.Fatalf("no NOT IMPLEMENTED line:\n%s", stdout) }
+    if stdout, stderr, code := runDriver(t, bin, "-validate-sections"); code != 0 { t.Fatalf("-validate-sections: exit code %d\n%s%s", code, stdout, stderr) }
+}
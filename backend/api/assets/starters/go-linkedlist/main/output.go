@@ -0,0 +1,145 @@
+package main
+
+import (
+    "bytes"
+    "fmt"
+    "io"
+    "os"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// outputWriter is the shared writer every task prints through. It is line
+// buffered so driver-wide modes can act on whole result lines no matter how
+// many Printf calls produced them.
+type outputWriter struct {
+    dst    io.Writer
+    buf    []byte
+    expect bool // follow each result line with "# EXPECT <line>" (memo builds only)
+    max    int  // per-section output cap in bytes; <= 0 disables it
+    v2     bool // write v2 headers: "&-=-& id=<name> title=\"<title>\""
+
+    sections []string // names of every header written, in order
+
+    // state of the current section for the output cap
+    size      int // bytes accepted so far, including any partial line
+    emitted   int // bytes of complete lines written
+    truncated bool
+}
+
+const (
+    expectPrefix           = "# EXPECT "
+    markerDelim            = "&-=-&" // v2 headers
+    noticePrefix           = "!!!"   // out-of-band notices such as truncation; never a header in either format
+    defaultMaxSectionBytes = 64 << 10
+    maxKVPairs             = 50
+)
+
+// Emitter receives everything a task prints: section headers, result text
+// and out-of-band notices, which are not expected output (such as printKV's
+// overflow marker). list is told the values of each list printList has just
+// printed, for formats that record them as data as well. *outputWriter is
+// the driver's implementation; tests can supply their own to capture a run.
+type Emitter interface {
+    io.Writer
+    header(name, title string)
+    notice(text string)
+    list(label string, values []int)
+    Flush()
+}
+
+var out = &outputWriter{dst: os.Stdout}
+
+// Write buffers p and emits every complete line. Once the current section
+// exceeds the cap, the lines that fit are kept, a single truncation marker is
+// written and the rest of the section is discarded.
+func (w *outputWriter) Write(p []byte) (int, error) {
+    if w.truncated { return len(p), nil }
+    if w.max > 0 && w.size+len(p) > w.max {
+        w.buf = append(w.buf, p[:w.max-w.size]...)
+        w.size = w.max
+        w.emitLines()
+        w.buf = w.buf[:0]
+        w.truncated = true
+        w.notice(fmt.Sprintf("truncated %s bytes=%d", w.current(), w.emitted))
+        return len(p), nil
+    }
+    w.size += len(p)
+    w.buf = append(w.buf, p...)
+    w.emitLines()
+    return len(p), nil
+}
+
+func (w *outputWriter) emitLines() {
+    for {
+        i := bytes.IndexByte(w.buf, '\n')
+        if i < 0 { break }
+        w.emit(w.buf[:i])
+        w.buf = w.buf[i+1:]
+    }
+}
+
+// header writes a section delimiter line and resets the per-section cap;
+// headers are never echoed as EXPECT lines. v1 headers carry only the name,
+// which the marker keys on; v2 headers add the quoted title.
+func (w *outputWriter) header(name, title string) {
+    w.Flush()
+    w.sections = append(w.sections, name)
+    w.size, w.emitted, w.truncated = 0, 0, false
+    if w.v2 {
+        fmt.Fprintf(w.dst, "%s id=%s title=%s\n", markerDelim, name, strconv.Quote(title))
+        return
+    }
+    fmt.Fprintf(w.dst, "%s %s\n", DELIM, name)
+}
+
+func (w *outputWriter) current() string {
+    if len(w.sections) == 0 { return "" }
+    return w.sections[len(w.sections)-1]
+}
+
+func (w *outputWriter) emit(line []byte) {
+    w.emitted += len(line) + 1
+    fmt.Fprintf(w.dst, "%s\n", line)
+    if w.expect { fmt.Fprintf(w.dst, "%s%s\n", expectPrefix, line) }
+}
+
+// notice writes an out-of-band line such as the truncation marker after any
+// pending partial line. It is never echoed as an EXPECT line, and its prefix
+// is neither header delimiter, so section parsers keep it as a body line of
+// the current section.
+func (w *outputWriter) notice(text string) {
+    w.Flush()
+    fmt.Fprintf(w.dst, "%s %s\n", noticePrefix, text)
+}
+
+// list does nothing: the text is all the transcript holds.
+func (w *outputWriter) list(label string, values []int) {}
+
+// Flush emits any trailing partial line.
+func (w *outputWriter) Flush() {
+    if len(w.buf) == 0 { return }
+    w.emit(w.buf)
+    w.buf = w.buf[:0]
+}
+
+// kvEscaper keeps each pair on one line and the first '=' unambiguous.
+var kvEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, "\n", `\n`, "\r", `\r`)
+
+// printKV prints pairs into the current section (name is used for the
+// overflow marker) as key=value lines sorted by key, escaping '=', '\' and
+// line breaks. Past maxKVPairs the remaining pairs are replaced by a single
+// overflow notice, which is not part of the expected output.
+func (r *taskRun) printKV(name string, pairs map[string]string) {
+    keys := make([]string, 0, len(pairs))
+    for k := range pairs { keys = append(keys, k) }
+    sort.Strings(keys)
+    for i, k := range keys {
+        if i == maxKVPairs {
+            r.e.notice(fmt.Sprintf("overflow %s omitted=%d", name, len(keys)-i))
+            break
+        }
+        r.printf("%s=%s\n", kvEscaper.Replace(k), kvEscaper.Replace(pairs[k]))
+    }
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+    "bufio"
+    "fmt"
+    "io"
+    "strconv"
+    "strings"
+)
+
+// -script mode reads driver commands from stdin, one per line, and runs them
+// against a single list, so a tutor can replay a student's failing sequence
+// without writing a task. Blank lines and lines starting with '#' are
+// skipped. Commands:
+//
+//	section <label>      start section Script<Label>; labels follow registerTask's rules
+//	push_front <v>       push_back <v>       pop_front
+//	insert <idx> <v>     remove <idx>        clear
+//	front    back    len    print
+//
+// A bad line prints "error line N: ..." and the script carries on; input text
+// is only ever echoed through scriptQuote. A command that reaches an
+// unimplemented stub prints "NOT IMPLEMENTED: <name>", as a task would, and
+// counts as failed.
+
+// maxScriptOps bounds how many commands one script may run.
+const maxScriptOps = 10000
+
+// scriptQuote quotes s for an error line. '&' is escaped as well so echoed
+// input can never contain the marker delimiter.
+func scriptQuote(s string) string { return strings.ReplaceAll(strconv.Quote(s), "&", `\x26`) }
+
+// scriptArgs lists the argument count of every command.
+var scriptArgs = map[string]int{
+    "section": 1, "push_front": 1, "push_back": 1, "pop_front": 0, "insert": 2,
+    "remove": 1, "clear": 0, "front": 0, "back": 0, "len": 0, "print": 0,
+}
+
+// runScript runs the commands read from in and returns how many it ran and
+// how many of those failed. It stops early, with an error line, on a line too
+// long to read or once maxScriptOps commands have run.
+func runScript(r *taskRun, in io.Reader) (ran, failed int) {
+    var lst ListAPI
+    if !catchTodo(r, func() { lst = newList() }) { return 0, 1 }
+    sc := bufio.NewScanner(in)
+    line := 0
+    for sc.Scan() {
+        line++
+        fields := strings.Fields(sc.Text())
+        if len(fields) == 0 || strings.HasPrefix(fields[0], "#") { continue }
+        if ran == maxScriptOps {
+            r.printf("error line %d: instruction budget of %d commands exhausted\n", line, maxScriptOps)
+            return ran, failed + 1
+        }
+        ran++
+        var err error
+        if !catchTodo(r, func() { err = runCommand(r, lst, fields) }) {
+            failed++
+        } else if err != nil {
+            r.printf("error line %d: %v\n", line, err)
+            failed++
+        }
+    }
+    if err := sc.Err(); err != nil {
+        r.printf("error line %d: %v\n", line+1, err)
+        failed++
+    }
+    return ran, failed
+}
+
+// catchTodo runs f and reports whether it returned. A stub's todoValue panic
+// is recovered and printed as runTask prints it; any other panic propagates.
+func catchTodo(r *taskRun, f func()) (ok bool) {
+    defer func() {
+        if ok { return }
+        v := recover()
+        todo, isTodo := v.(todoValue)
+        if !isTodo { panic(v) }
+        r.printf("NOT IMPLEMENTED: %s\n", todo.NotImplemented())
+    }()
+    f()
+    return true
+}
+
+func runCommand(r *taskRun, lst ListAPI, fields []string) error {
+    cmd, args := fields[0], fields[1:]
+    want, known := scriptArgs[cmd]
+    if !known { return fmt.Errorf("unknown command %s", scriptQuote(cmd)) }
+    if len(args) != want { return fmt.Errorf("%s takes %d argument(s), got %d", cmd, want, len(args)) }
+    if cmd == "section" {
+        if !validSectionName.MatchString(args[0]) { return fmt.Errorf("invalid section name %s", scriptQuote(args[0])) }
+        r.section(subtask("Script", args[0]))
+        return nil
+    }
+    nums := make([]int, len(args))
+    for i, a := range args {
+        n, err := strconv.Atoi(a)
+        if err != nil { return fmt.Errorf("%s: argument %s is not an int", cmd, scriptQuote(a)) }
+        nums[i] = n
+    }
+    switch cmd {
+    case "push_front": lst.PushFront(nums[0])
+    case "push_back": lst.PushBack(nums[0])
+    case "pop_front":
+        ok, v := lst.PopFront()
+        r.printf("ok=%t popped=%d\n", ok, v)
+    case "insert": r.printf("ok=%t\n", lst.InsertAt(nums[0], nums[1]))
+    case "remove": r.printf("ok=%t\n", lst.RemoveAt(nums[0]))
+    case "clear": lst.Clear()
+    case "front":
+        v, ok := lst.Front()
+        r.printf("front=%d ok=%t\n", v, ok)
+    case "back":
+        v, ok := lst.Back()
+        r.printf("back=%d ok=%t\n", v, ok)
+    case "len": r.printf("empty=%t size=%d\n", lst.IsEmpty(), lst.Len())
+    case "print": r.printList(lst, "")
+    }
+    return nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+    "strings"
+    "testing"
+)
+
+// runScriptText runs script through a fresh v2 writer and returns the
+// transcript along with the writer, whose sections list the headers written.
+func runScriptText(script string) (string, *outputWriter, int, int) {
+    var buf strings.Builder
+    w := &outputWriter{dst: &buf, max: defaultMaxSectionBytes, v2: true}
+    ran, failed := runScript(&taskRun{e: w}, strings.NewReader(script))
+    w.Flush()
+    return buf.String(), w, ran, failed
+}
+
+func TestScript(t *testing.T) {
+    script := `# replay of a tail bug
+section push
+push_back 1
+push_front 0
+insert 2 5
+print
+
+section pop
+pop_front
+remove 1
+back
+len
+frobnicate
+push_back
+push_back 99999999999999999999
+clear
+front
+`
+    want := `&-=-& id=ScriptPush title=""
+ok=true
+[0 1 5] size=3
+&-=-& id=ScriptPop title=""
+ok=true popped=0
+ok=true
+back=1 ok=true
+empty=false size=1
+error line 13: unknown command "frobnicate"
+error line 14: push_back takes 1 argument(s), got 0
+error line 15: push_back: argument "99999999999999999999" is not an int
+front=0 ok=false
+`
+    got, _, ran, failed := runScriptText(script)
+    if got != want { t.Fatalf("transcript:\n%s\nwant:\n%s", got, want) }
+    if ran != 15 || failed != 3 { t.Fatalf("ran=%d failed=%d, want 15 and 3", ran, failed) }
+}
+
+func TestScriptBudget(t *testing.T) {
+    got, _, ran, failed := runScriptText(strings.Repeat("clear\n", maxScriptOps+5))
+    if ran != maxScriptOps || failed != 1 { t.Fatalf("ran=%d failed=%d, want %d and 1", ran, failed, maxScriptOps) }
+    if !strings.HasSuffix(got, "instruction budget of 10000 commands exhausted\n") { t.Fatalf("no budget error at the end:\n...%s", got) }
+}
+
+// todoList is a list whose Back and RemoveAt are unimplemented stubs.
+type todoList struct{ ListAPI }
+
+func (todoList) Back() (int, bool)  { panic(fakeTodo("LinkedList.Back")) }
+func (todoList) RemoveAt(int) bool { panic(fakeTodo("LinkedList.RemoveAt")) }
+
+func TestScriptNotImplemented(t *testing.T) {
+    defer func(orig func() ListAPI) { newList = orig }(newList)
+    newList = func() ListAPI { return todoList{New()} }
+    got, _, ran, failed := runScriptText("section s\npush_back 1\nback\nremove 0\nlen\n")
+    want := `&-=-& id=ScriptS title=""
+NOT IMPLEMENTED: LinkedList.Back
+NOT IMPLEMENTED: LinkedList.RemoveAt
+empty=false size=1
+`
+    if got != want { t.Fatalf("transcript:\n%s\nwant:\n%s", got, want) }
+    if ran != 5 || failed != 2 { t.Fatalf("ran=%d failed=%d, want 5 and 2", ran, failed) }
+
+    newList = func() ListAPI { panic(fakeTodo("New")) }
+    got, _, ran, failed = runScriptText("len\n")
+    if got != "NOT IMPLEMENTED: New\n" || ran != 0 || failed != 1 { t.Fatalf("stub New: ran=%d failed=%d\n%s", ran, failed, got) }
+}
+
+func TestScriptOtherPanicsPropagate(t *testing.T) {
+    defer func(orig func() ListAPI) { newList = orig }(newList)
+    newList = func() ListAPI { panic("boom") }
+    defer func() {
+        if v := recover(); v != "boom" { t.Fatalf("recovered %v, want the list's own panic", v) }
+    }()
+    runScriptText("len\n")
+    t.Fatal("panic was swallowed")
+}
+
+// scriptRegressions seeds FuzzScript with marker-like input and extreme
+// indices; add the reduced input of any crash found by fuzzing here.
+var scriptRegressions = []string{
+    "section \x00&-=-&\n",
+    "&-=-& id=ScriptX\n",
+    "insert 0 -9223372036854775808\nremove 9223372036854775807\nprint\n",
+}
+
+// FuzzScript feeds arbitrary input to the interpreter. It must not panic,
+// must stay within maxScriptOps, and every line carrying the marker delimiter
+// must be a header it wrote itself. From the starter root, after ./test.sh:
+//
+//	cd .build && GO111MODULE=off go test -run XXX -fuzz=FuzzScript -fuzztime=30s .
+func FuzzScript(f *testing.F) {
+    for cmd, n := range scriptArgs {
+        f.Add(cmd + strings.Repeat(" 1", n) + "\n")
+        if n > 0 { f.Add(cmd + "\n") }
+        f.Add(cmd + strings.Repeat(" 99999999999999999999999", n) + "\n")
+        f.Add(cmd + strings.Repeat(" -9223372036854775808", n) + "\n")
+    }
+    f.Add("section a\npush_back 1\npush_back 2\ninsert 1 7\nremove 0\nprint\nsection b\npop_front\nfront\nback\nlen\nclear\n")
+    for _, s := range scriptRegressions { f.Add(s) }
+
+    f.Fuzz(func(t *testing.T, script string) {
+        got, w, ran, _ := runScriptText(script)
+        if ran > maxScriptOps { t.Fatalf("ran %d commands, budget %d", ran, maxScriptOps) }
+        headers := 0
+        for _, line := range strings.Split(got, "\n") {
+            if !strings.Contains(line, markerDelim) { continue }
+            switch {
+            case strings.HasPrefix(line, markerDelim+" id=Script") && headers < len(w.sections):
+                if want := markerDelim + " id=" + w.sections[headers] + ` title=""`; line != want { t.Fatalf("header %q, want %q", line, want) }
+                headers++
+            default:
+                t.Fatalf("unescaped marker in output line %q\nscript: %q", line, script)
+            }
+        }
+        if headers != len(w.sections) { t.Fatalf("%d header lines for %d sections", headers, len(w.sections)) }
+    })
+}
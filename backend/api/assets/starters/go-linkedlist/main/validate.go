@@ -0,0 +1,57 @@
+package main
+
+import (
+    "errors"
+    "fmt"
+    "io"
+    "strings"
+)
+
+// ValidateSectionsEmitted compares the section names a task printed against the
+// names the schema expects and returns an error listing any that are missing or
+// extra, so a forgotten section(...) call is caught before it costs marks.
+func ValidateSectionsEmitted(emitted, expected []string) error {
+    seen := make(map[string]bool, len(emitted))
+    for _, name := range emitted { seen[name] = true }
+    want := make(map[string]bool, len(expected))
+    for _, name := range expected { want[name] = true }
+
+    var missing, extra []string
+    for _, name := range expected {
+        if !seen[name] { missing = append(missing, name) }
+    }
+    for _, name := range emitted {
+        if !want[name] { extra = append(extra, name) }
+    }
+    if len(missing) == 0 && len(extra) == 0 { return nil }
+
+    var parts []string
+    if len(missing) > 0 { parts = append(parts, "missing sections: "+strings.Join(missing, ", ")) }
+    if len(extra) > 0 { parts = append(parts, "extra sections: "+strings.Join(extra, ", ")) }
+    return errors.New(strings.Join(parts, "; "))
+}
+
+// expectedSections expands a task's labels into the nested names it should emit.
+func expectedSections(t driverTask) []string {
+    names := make([]string, len(t.sections))
+    for i, label := range t.sections { names[i] = subtask(t.prefix, label) }
+    return names
+}
+
+// validateSections runs each task with its output discarded, checks the
+// captured section names and reports one line per task. It returns false if
+// any task failed validation.
+func validateSections(selected []driverTask) bool {
+    ok := true
+    for _, t := range selected {
+        w := &outputWriter{dst: io.Discard}
+        runTasks(w, []driverTask{t})
+        if err := ValidateSectionsEmitted(w.sections, expectedSections(t)); err != nil {
+            fmt.Fprintf(out.dst, "%s: %v\n", t.name, err)
+            ok = false
+        } else {
+            fmt.Fprintf(out.dst, "%s: ok (%d sections)\n", t.name, len(w.sections))
+        }
+    }
+    return ok
+}
@@ -0,0 +1,333 @@
+//go:build altslice || altnotail
+
+package main
+
+import (
+    "errors"
+    "sort"
+    "strconv"
+)
+
+// The rest of the memo's API, each written over the values in a slice. The
+// doc comments of memo/linked_list.go say what they must do.
+
+func BuildFromRange(start, end, step int) *LinkedList {
+    var vs []int
+    for v := start; step > 0 && v < end || step < 0 && v > end; v += step { vs = append(vs, v) }
+    return fromValues(vs)
+}
+
+func (l *LinkedList) RemoveLast(v int) bool {
+    vs := l.ToSlice()
+    for i := len(vs) - 1; i >= 0; i-- {
+        if vs[i] == v { return l.RemoveAt(i) }
+    }
+    return false
+}
+
+func (l *LinkedList) EnforceMaxLength(n int, dropFront bool) {
+    vs := l.ToSlice()
+    if n < 0 { n = 0 }
+    if len(vs) <= n { return }
+    if dropFront { l.reset(vs[len(vs)-n:]) } else { l.reset(vs[:n]) }
+}
+
+func (l *LinkedList) Do(fn func(int) bool) {
+    for _, v := range l.ToSlice() {
+        if !fn(v) { return }
+    }
+}
+
+func (l *LinkedList) ToSliceCapped(max int) ([]int, bool) {
+    vs := l.ToSlice()
+    if max < 0 { max = 0 }
+    if len(vs) <= max { return vs, false }
+    return vs[:max], true
+}
+
+func (l *LinkedList) PeekN(n int) []int {
+    vs, _ := l.ToSliceCapped(n)
+    return vs
+}
+
+func (l *LinkedList) AsStringSlice(fmtFn func(int) string) []string {
+    if fmtFn == nil { fmtFn = strconv.Itoa }
+    out := []string{}
+    for _, v := range l.ToSlice() { out = append(out, fmtFn(v)) }
+    return out
+}
+
+func (l *LinkedList) Tee(n int) []*LinkedList {
+    var copies []*LinkedList
+    for i := 0; i < n; i++ { copies = append(copies, l.Copy()) }
+    return copies
+}
+
+func (l *LinkedList) CopyReversed() *LinkedList {
+    vs := l.ToSlice()
+    for i, j := 0, len(vs)-1; i < j; i, j = i+1, j-1 { vs[i], vs[j] = vs[j], vs[i] }
+    return fromValues(vs)
+}
+
+func (l *LinkedList) Frequencies() (values []int, counts []int) {
+    vs := l.ToSlice()
+    sort.Ints(vs)
+    values, counts = []int{}, []int{}
+    for i, v := range vs {
+        if i > 0 && v == vs[i-1] { counts[len(counts)-1]++; continue }
+        values, counts = append(values, v), append(counts, 1)
+    }
+    return values, counts
+}
+
+func (l *LinkedList) CountValuesExceeding(threshold int) int {
+    count := 0
+    for _, v := range l.ToSlice() {
+        if v > threshold { count++ }
+    }
+    return count
+}
+
+func (l *LinkedList) SumValuesExceeding(threshold int) int {
+    sum := 0
+    for _, v := range l.ToSlice() {
+        if v > threshold { sum += v }
+    }
+    return sum
+}
+
+func (l *LinkedList) IsSorted() bool { return sort.IntsAreSorted(l.ToSlice()) }
+
+func (l *LinkedList) IsSortedDesc() bool {
+    vs := l.ToSlice()
+    return sort.SliceIsSorted(vs, func(i, j int) bool { return vs[i] > vs[j] })
+}
+
+func (l *LinkedList) MaxGap() (int, bool) {
+    vs := l.ToSlice()
+    if len(vs) < 2 { return 0, false }
+    sort.Ints(vs)
+    gap := 0
+    for i := 1; i < len(vs); i++ {
+        if vs[i]-vs[i-1] > gap { gap = vs[i] - vs[i-1] }
+    }
+    return gap, true
+}
+
+func (l *LinkedList) SegmentSums(segmentLengths []int) ([]int, bool) {
+    vs := l.ToSlice()
+    sums := []int{}
+    for _, n := range segmentLengths {
+        if n < 0 || n > len(vs) { return nil, false }
+        sum := 0
+        for _, v := range vs[:n] { sum += v }
+        sums, vs = append(sums, sum), vs[n:]
+    }
+    if len(vs) > 0 { return nil, false }
+    return sums, true
+}
+
+func (l *LinkedList) ScanLeft(init int, fn func(acc, v int) int) *LinkedList {
+    var out []int
+    for _, v := range l.ToSlice() {
+        init = fn(init, v)
+        out = append(out, init)
+    }
+    return fromValues(out)
+}
+
+func (l *LinkedList) Deinterleave() (evenPos, oddPos *LinkedList) {
+    var even, odd []int
+    for i, v := range l.ToSlice() {
+        if i%2 == 0 { even = append(even, v) } else { odd = append(odd, v) }
+    }
+    return fromValues(even), fromValues(odd)
+}
+
+func (l *LinkedList) ToPairs() [][2]int {
+    vs := l.ToSlice()
+    pairs := [][2]int{}
+    for i := 0; i+1 < len(vs); i++ { pairs = append(pairs, [2]int{vs[i], vs[i+1]}) }
+    return pairs
+}
+
+func (l *LinkedList) BucketBy(key func(int) int) map[int]*LinkedList {
+    groups := map[int][]int{}
+    for _, v := range l.ToSlice() { groups[key(v)] = append(groups[key(v)], v) }
+    buckets := map[int]*LinkedList{}
+    for k, vs := range groups { buckets[k] = fromValues(vs) }
+    return buckets
+}
+
+func (l *LinkedList) ReplaceAll(old, new int) int {
+    vs, count := l.ToSlice(), 0
+    for i, v := range vs {
+        if v == old { vs[i] = new; count++ }
+    }
+    l.reset(vs)
+    return count
+}
+
+func (l *LinkedList) ReplaceFirst(old, new int) bool {
+    vs := l.ToSlice()
+    for i, v := range vs {
+        if v == old { return l.ApplyAt(i, func(int) int { return new }) }
+    }
+    return false
+}
+
+func (l *LinkedList) ApplyAt(idx int, fn func(int) int) bool {
+    vs := l.ToSlice()
+    if idx < 0 || idx >= len(vs) { return false }
+    vs[idx] = fn(vs[idx])
+    l.reset(vs)
+    return true
+}
+
+func (l *LinkedList) Clamp(lo, hi int) {
+    vs := l.ToSlice()
+    for i, v := range vs {
+        if v < lo { vs[i] = lo } else if v > hi { vs[i] = hi }
+    }
+    l.reset(vs)
+}
+
+func (l *LinkedList) NormalizeToRange(lo, hi int) {
+    vs := l.ToSlice()
+    if len(vs) == 0 { return }
+    sorted := append([]int(nil), vs...)
+    sort.Ints(sorted)
+    min, max := sorted[0], sorted[len(sorted)-1]
+    if min == max { return }
+    for i, v := range vs {
+        // Round half away from zero with exact integer arithmetic: twice
+        // the numerator against the denominator.
+        num, den := 2*(v-min)*(hi-lo), 2*(max-min)
+        if num >= 0 { vs[i] = lo + (num+den/2)/den } else { vs[i] = lo - (-num+den/2)/den }
+    }
+    l.reset(vs)
+}
+
+func (l *LinkedList) UniqueCounting() int {
+    vs := l.ToSlice()
+    var kept []int
+    for i, v := range vs {
+        if i == 0 || v != vs[i-1] { kept = append(kept, v) }
+    }
+    l.reset(kept)
+    return len(vs) - len(kept)
+}
+
+func (l *LinkedList) RemoveIfAdjacentEqual() {
+    vs := l.ToSlice()
+    for changed := true; changed; {
+        changed = false
+        var kept []int
+        for i := 0; i < len(vs); i++ {
+            if i+1 < len(vs) && vs[i] == vs[i+1] { i++; changed = true; continue }
+            kept = append(kept, vs[i])
+        }
+        vs = kept
+    }
+    l.reset(vs)
+}
+
+func (l *LinkedList) RemoveWhereIndex(pred func(index int) bool) int {
+    vs := l.ToSlice()
+    var kept []int
+    for i, v := range vs {
+        if !pred(i) { kept = append(kept, v) }
+    }
+    l.reset(kept)
+    return len(vs) - len(kept)
+}
+
+func (l *LinkedList) InsertSortedUnique(v int) bool {
+    vs := l.ToSlice()
+    i := 0
+    for i < len(vs) && vs[i] < v { i++ }
+    if i < len(vs) && vs[i] == v { return false }
+    return l.InsertAt(i, v)
+}
+
+var ErrNotSorted = errors.New("value is smaller than the back of the list")
+
+func (l *LinkedList) PushBackSorted(v int) error {
+    if back, ok := l.Back(); ok && v < back { return ErrNotSorted }
+    l.PushBack(v)
+    return nil
+}
+
+func (l *LinkedList) SwapPairs() {
+    vs := l.ToSlice()
+    for i := 0; i+1 < len(vs); i += 2 { vs[i], vs[i+1] = vs[i+1], vs[i] }
+    l.reset(vs)
+}
+
+func (l *LinkedList) InterquartileTrim() {
+    vs := l.ToSlice()
+    if len(vs) == 0 { return }
+    sorted := append([]int(nil), vs...)
+    sort.Ints(sorted)
+    quartile := func(p float64) float64 {
+        rank := float64(len(sorted)-1) * p
+        below := int(rank)
+        if below == len(sorted)-1 { return float64(sorted[below]) }
+        return float64(sorted[below]) + (rank-float64(below))*float64(sorted[below+1]-sorted[below])
+    }
+    q1, q3 := quartile(0.25), quartile(0.75)
+    var kept []int
+    for _, v := range vs {
+        if f := float64(v); f >= q1-1.5*(q3-q1) && f <= q3+1.5*(q3-q1) { kept = append(kept, v) }
+    }
+    l.reset(kept)
+}
+
+func (l *LinkedList) RotateUntilSorted() (rotations int, ok bool) {
+    vs := l.ToSlice()
+    for r := 0; r < len(vs); r++ {
+        rotated := append(append([]int(nil), vs[r:]...), vs[:r]...)
+        if sort.IntsAreSorted(rotated) {
+            l.reset(rotated)
+            return r, true
+        }
+    }
+    return 0, len(vs) == 0
+}
+
+func (l *LinkedList) WindowMax(k int) []int {
+    vs := l.ToSlice()
+    maxes := []int{}
+    if k <= 0 { return maxes }
+    for i := 0; i+k <= len(vs); i++ {
+        m := vs[i]
+        for _, v := range vs[i : i+k] {
+            if v > m { m = v }
+        }
+        maxes = append(maxes, m)
+    }
+    return maxes
+}
+
+// DoublyLinkedList keeps its values in a slice; only the results of the
+// memo's DoublyLinkedList are reproduced, not its links.
+type DoublyLinkedList struct{ vs []int }
+
+func NewDoubly() *DoublyLinkedList { return &DoublyLinkedList{} }
+
+func (d *DoublyLinkedList) Len() int { return len(d.vs) }
+
+func (d *DoublyLinkedList) PushBack(v int) { d.vs = append(d.vs, v) }
+
+func (d *DoublyLinkedList) InsertSorted(v int) {
+    i := sort.Search(len(d.vs), func(i int) bool { return d.vs[i] > v })
+    d.vs = append(d.vs[:i], append([]int{v}, d.vs[i:]...)...)
+}
+
+func (d *DoublyLinkedList) ToSlice() []int { return append([]int{}, d.vs...) }
+
+func (d *DoublyLinkedList) ToSliceReverse() []int {
+    out := d.ToSlice()
+    for i, j := 0, len(out)-1; i < j; i, j = i+1, j-1 { out[i], out[j] = out[j], out[i] }
+    return out
+}
@@ -0,0 +1,10 @@
+//go:build altnotail
+
+package main
+
+import "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/altimpl"
+
+// -tags altnotail runs the tasks against altimpl.NoTailList, which has
+// neither a tail pointer nor a size, so no stale tail or miscounted size can
+// hide in it.
+var newImpl = altimpl.NewNoTailList
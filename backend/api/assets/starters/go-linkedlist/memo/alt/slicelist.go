@@ -0,0 +1,9 @@
+//go:build altslice
+
+package main
+
+import "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/altimpl"
+
+// -tags altslice runs the tasks against altimpl.SliceList, whose values live
+// in a slice, so none of the memo's node handling is shared.
+var newImpl = altimpl.NewSliceList
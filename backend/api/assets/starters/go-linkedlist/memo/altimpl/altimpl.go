@@ -0,0 +1,22 @@
+// Package altimpl holds deliberately naive but correct list implementations.
+// The memo's tests use them as extra oracles for the model tests, and its
+// benchmarks time them against the memo so authors can see what a slow but
+// working submission costs on each workload. memo/alt builds the driver
+// against them for differential grading, and test.sh runs the driver's
+// whole test suite, golden transcripts included, on each.
+package altimpl
+
+// List is the operation set shared by the memo's LinkedList and the
+// implementations here: the operations the model and stress tests apply.
+type List interface {
+    PushFront(v int)
+    PushBack(v int)
+    PopFront() (bool, int)
+    Front() (int, bool)
+    Back() (int, bool)
+    InsertAt(idx int, v int) bool
+    RemoveAt(idx int) bool
+    Clear()
+    Len() int
+    ToSlice() []int
+}
@@ -0,0 +1,74 @@
+package altimpl
+
+type node struct {
+    val  int
+    next *node
+}
+
+// NoTailList is a singly linked list without a tail pointer or a size field,
+// the usual first attempt: PushBack, Back and Len walk the whole chain.
+type NoTailList struct{ head *node }
+
+func NewNoTailList() List { return &NoTailList{} }
+
+func (l *NoTailList) last() *node {
+    if l.head == nil { return nil }
+    n := l.head
+    for n.next != nil { n = n.next }
+    return n
+}
+
+func (l *NoTailList) PushFront(v int) { l.head = &node{val: v, next: l.head} }
+
+func (l *NoTailList) PushBack(v int) {
+    if last := l.last(); last != nil { last.next = &node{val: v} } else { l.head = &node{val: v} }
+}
+
+func (l *NoTailList) PopFront() (bool, int) {
+    if l.head == nil { return false, 0 }
+    v := l.head.val
+    l.head = l.head.next
+    return true, v
+}
+
+func (l *NoTailList) Front() (int, bool) {
+    if l.head == nil { return 0, false }
+    return l.head.val, true
+}
+
+func (l *NoTailList) Back() (int, bool) {
+    if last := l.last(); last != nil { return last.val, true }
+    return 0, false
+}
+
+func (l *NoTailList) InsertAt(idx int, v int) bool {
+    if idx < 0 || idx > l.Len() { return false }
+    if idx == 0 { l.PushFront(v); return true }
+    prev := l.head
+    for i := 0; i < idx-1; i++ { prev = prev.next }
+    prev.next = &node{val: v, next: prev.next}
+    return true
+}
+
+func (l *NoTailList) RemoveAt(idx int) bool {
+    if idx < 0 || idx >= l.Len() { return false }
+    if idx == 0 { l.head = l.head.next; return true }
+    prev := l.head
+    for i := 0; i < idx-1; i++ { prev = prev.next }
+    prev.next = prev.next.next
+    return true
+}
+
+func (l *NoTailList) Clear() { l.head = nil }
+
+func (l *NoTailList) Len() int {
+    n := 0
+    for p := l.head; p != nil; p = p.next { n++ }
+    return n
+}
+
+func (l *NoTailList) ToSlice() []int {
+    vs := []int{}
+    for p := l.head; p != nil; p = p.next { vs = append(vs, p.val) }
+    return vs
+}
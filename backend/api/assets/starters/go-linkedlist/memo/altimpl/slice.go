@@ -0,0 +1,51 @@
+package altimpl
+
+// SliceList keeps the values in a slice. Every front operation shifts the
+// whole slice, so PushFront and PopFront are O(n) where the memo is O(1).
+type SliceList struct{ vs []int }
+
+func NewSliceList() List { return &SliceList{} }
+
+func (l *SliceList) PushFront(v int) {
+    l.vs = append(l.vs, 0)
+    copy(l.vs[1:], l.vs)
+    l.vs[0] = v
+}
+
+func (l *SliceList) PushBack(v int) { l.vs = append(l.vs, v) }
+
+func (l *SliceList) PopFront() (bool, int) {
+    if len(l.vs) == 0 { return false, 0 }
+    v := l.vs[0]
+    copy(l.vs, l.vs[1:])
+    l.vs = l.vs[:len(l.vs)-1]
+    return true, v
+}
+
+func (l *SliceList) Front() (int, bool) {
+    if len(l.vs) == 0 { return 0, false }
+    return l.vs[0], true
+}
+
+func (l *SliceList) Back() (int, bool) {
+    if len(l.vs) == 0 { return 0, false }
+    return l.vs[len(l.vs)-1], true
+}
+
+func (l *SliceList) InsertAt(idx int, v int) bool {
+    if idx < 0 || idx > len(l.vs) { return false }
+    l.vs = append(l.vs, 0)
+    copy(l.vs[idx+1:], l.vs[idx:])
+    l.vs[idx] = v
+    return true
+}
+
+func (l *SliceList) RemoveAt(idx int) bool {
+    if idx < 0 || idx >= len(l.vs) { return false }
+    l.vs = append(l.vs[:idx], l.vs[idx+1:]...)
+    return true
+}
+
+func (l *SliceList) Clear() { l.vs = nil }
+func (l *SliceList) Len() int { return len(l.vs) }
+func (l *SliceList) ToSlice() []int { return append([]int{}, l.vs...) }
@@ -0,0 +1,83 @@
+package main
+
+// ArrayList is a slice-backed list with the same core methods as LinkedList,
+// for comparing the two: indexing is O(1), but inserting or removing
+// anywhere but the back shifts every later value.
+type ArrayList struct {
+    data []int
+}
+
+// NewArrayList returns an empty ArrayList.
+func NewArrayList() *ArrayList { return &ArrayList{} }
+
+// NewWithCapacity returns an empty ArrayList with room for n values before
+// it has to grow; a negative n is treated as 0.
+func NewWithCapacity(n int) *ArrayList {
+    if n < 0 { n = 0 }
+    return &ArrayList{data: make([]int, 0, n)}
+}
+
+// Len returns the number of values.
+func (a *ArrayList) Len() int { return len(a.data) }
+
+// IsEmpty reports whether the list holds no values.
+func (a *ArrayList) IsEmpty() bool { return len(a.data) == 0 }
+
+// Cap returns how many values the list can hold before it has to grow.
+func (a *ArrayList) Cap() int { return cap(a.data) }
+
+// Clear removes every value but keeps the capacity for reuse.
+func (a *ArrayList) Clear() { a.data = a.data[:0] }
+
+// PushFront inserts v before the first value, shifting every value along.
+func (a *ArrayList) PushFront(v int) { a.InsertAt(0, v) }
+
+// PushBack appends v after the last value in amortised O(1).
+func (a *ArrayList) PushBack(v int) { a.data = append(a.data, v) }
+
+// PopFront removes the first value and returns true and that value, or
+// (false, 0) when the list is empty.
+func (a *ArrayList) PopFront() (bool, int) {
+    if len(a.data) == 0 { return false, 0 }
+    v := a.data[0]
+    a.RemoveAt(0)
+    return true, v
+}
+
+// Front returns the first value and true, or (0, false) when the list is
+// empty.
+func (a *ArrayList) Front() (int, bool) { return a.At(0) }
+
+// Back returns the last value and true, or (0, false) when the list is
+// empty.
+func (a *ArrayList) Back() (int, bool) { return a.At(len(a.data) - 1) }
+
+// At returns the value at index idx and true in O(1), or (0, false) when idx
+// is outside [0, Len).
+func (a *ArrayList) At(idx int) (int, bool) {
+    if idx < 0 || idx >= len(a.data) { return 0, false }
+    return a.data[idx], true
+}
+
+// InsertAt inserts v so that it ends up at index idx and reports true.
+// idx may equal Len, which appends; a negative idx or one past Len leaves
+// the list unchanged and reports false.
+func (a *ArrayList) InsertAt(idx int, v int) bool {
+    if idx < 0 || idx > len(a.data) { return false }
+    a.data = append(a.data, 0)
+    copy(a.data[idx+1:], a.data[idx:])
+    a.data[idx] = v
+    return true
+}
+
+// RemoveAt removes the value at index idx and reports true, or reports
+// false and leaves the list unchanged when idx is outside [0, Len).
+func (a *ArrayList) RemoveAt(idx int) bool {
+    if idx < 0 || idx >= len(a.data) { return false }
+    a.data = append(a.data[:idx], a.data[idx+1:]...)
+    return true
+}
+
+// ToSlice returns the values from front to back in a new slice; an empty
+// list gives an empty, non-nil slice.
+func (a *ArrayList) ToSlice() []int { return append([]int{}, a.data...) }
@@ -0,0 +1,50 @@
+package main
+
+import (
+    "testing"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/listtest"
+)
+
+// The model tests cover ArrayList's shared operations (it is in impls);
+// these cover what only ArrayList has.
+
+func TestNewWithCapacity(t *testing.T) {
+    t.Parallel()
+    for _, c := range []struct{ n, cap int }{{0, 0}, {1, 1}, {64, 64}, {-3, 0}} {
+        a := NewWithCapacity(c.n)
+        if a.Len() != 0 || a.Cap() != c.cap { t.Errorf("NewWithCapacity(%d): Len %d, Cap %d, want 0, %d", c.n, a.Len(), a.Cap(), c.cap) }
+    }
+    a := NewWithCapacity(4)
+    for i := 0; i < 4; i++ { a.PushBack(i) }
+    if a.Cap() != 4 { t.Fatalf("Cap() = %d after filling the hint, want 4", a.Cap()) }
+    a.Clear()
+    listtest.AssertEmpty(t, a)
+    if a.Cap() != 4 { t.Fatalf("Cap() = %d after Clear, want 4", a.Cap()) }
+    a.PushBack(100)
+    listtest.AssertList(t, a, []int{100})
+}
+
+func TestArrayListAt(t *testing.T) {
+    t.Parallel()
+    a := NewArrayList()
+    for _, v := range []int{4, 5, 6} { a.PushBack(v) }
+    tests := []struct {
+        idx  int
+        want int
+        ok   bool
+    }{{0, 4, true}, {2, 6, true}, {-1, 0, false}, {3, 0, false}}
+    for _, tt := range tests {
+        if v, ok := a.At(tt.idx); v != tt.want || ok != tt.ok { t.Errorf("At(%d) = (%d, %t), want (%d, %t)", tt.idx, v, ok, tt.want, tt.ok) }
+    }
+    if v, ok := NewArrayList().At(0); v != 0 || ok { t.Errorf("empty At(0) = (%d, %t), want (0, false)", v, ok) }
+}
+
+func TestArrayListToSliceIsACopy(t *testing.T) {
+    t.Parallel()
+    a := NewArrayList()
+    if got := a.ToSlice(); got == nil || len(got) != 0 { t.Fatalf("empty ToSlice() = %#v, want []int{}", got) }
+    a.PushBack(1)
+    a.ToSlice()[0] = 9
+    if v, _ := a.Front(); v != 1 { t.Fatalf("writing to ToSlice's result changed the list: Front() = %d", v) }
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+    "fmt"
+    "testing"
+)
+
+// indexMethods lists every method that takes an index, wrapped to report
+// success. Add a row here when a new index-taking method is added and it is
+// covered by the boundary matrix below.
+var indexMethods = []struct {
+    name string
+    call func(l *LinkedList, idx int) bool
+}{
+    {"InsertAt", func(l *LinkedList, idx int) bool { return l.InsertAt(idx, 42) }},
+    {"RemoveAt", func(l *LinkedList, idx int) bool { return l.RemoveAt(idx) }},
+    {"ApplyAt", func(l *LinkedList, idx int) bool { return l.ApplyAt(idx, func(v int) int { return v + 1 }) }},
+}
+
+// TestNoPanicsOnBoundaryIndices calls every index method with indices around
+// and far beyond the valid range. Each call must return rather than panic,
+// and a call that reports failure must leave the list exactly as it was.
+func TestNoPanicsOnBoundaryIndices(t *testing.T) {
+    for _, m := range indexMethods {
+        for _, size := range []int{0, 1, 10} {
+            for _, idx := range []int{-1 << 30, -1, 0, size - 1, size, size + 1, 1 << 30} {
+                t.Run(fmt.Sprintf("%s/size=%d/idx=%d", m.name, size, idx), func(t *testing.T) {
+                    seed := BuildFromRange(0, size, 1).ToSlice()
+                    l := fromSlice(seed)
+                    var ok bool
+                    func() {
+                        defer func() {
+                            if r := recover(); r != nil { t.Fatalf("%s(%d) on %d elements panicked: %v", m.name, idx, size, r) }
+                        }()
+                        ok = m.call(l, idx)
+                    }()
+                    if err := invariantErr(l); err != nil { t.Fatalf("%s(%d) on %d elements: %v", m.name, idx, size, err) }
+                    if !ok { checkList(t, l, seed) }
+                })
+            }
+        }
+    }
+}
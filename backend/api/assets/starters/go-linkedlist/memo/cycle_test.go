@@ -0,0 +1,61 @@
+package main
+
+import (
+    "fmt"
+    "testing"
+    "time"
+)
+
+// watchdog bounds every call on a cyclic list. The lists here are tiny, so a
+// method that takes the 2*size+2 steps a cycle-safe walk needs returns in
+// microseconds; only an unbounded loop reaches the deadline.
+const watchdog = 2 * time.Second
+
+// bounded runs fn and returns once it does. If fn is still running after
+// watchdog it panics, as go test -timeout would but without the long wait:
+// the looping goroutine cannot be stopped and, if it allocates, would run
+// the process out of memory before a plain test failure got reported. The
+// stack dump shows where fn is stuck.
+func bounded(what string, fn func()) {
+    done := make(chan struct{})
+    go func() { defer close(done); fn() }()
+    select {
+    case <-done:
+    case <-time.After(watchdog):
+        panic(fmt.Sprintf("%s did not return within %v: unbounded walk of a cyclic list", what, watchdog))
+    }
+}
+
+// TestCycleSafeMethods corrupts lists into cycles and checks that the
+// methods meant for corrupted or untrusted lists still return: CycleLength
+// and the invariant check must also report the cycle, and the capped copies
+// must stop after at most Len values.
+func TestCycleSafeMethods(t *testing.T) {
+    cases := []struct{ size, pos int }{{1, 0}, {5, 0}, {5, 2}, {5, 4}}
+    for _, c := range cases {
+        t.Run(fmt.Sprintf("size=%d/pos=%d", c.size, c.pos), func(t *testing.T) {
+            l := BuildFromRange(0, c.size, 1)
+            makeCycleForTest(l, c.pos)
+
+            var n int
+            var ok bool
+            bounded("CycleLength", func() { n, ok = l.CycleLength() })
+            if n != c.size-c.pos || !ok { t.Errorf("CycleLength() = (%d, %t), want (%d, true)", n, ok, c.size-c.pos) }
+            var err error
+            bounded("invariantErr", func() { err = invariantErr(l) })
+            if err == nil { t.Errorf("invariantErr reported no problem with a cycle") }
+            var vs []int
+            bounded("ToSliceCapped", func() { vs, _ = l.ToSliceCapped(1 << 20) })
+            if len(vs) > c.size { t.Errorf("ToSliceCapped returned %d values from a %d-node list", len(vs), c.size) }
+            bounded("PeekN", func() { vs = l.PeekN(1 << 20) })
+            if len(vs) > c.size { t.Errorf("PeekN returned %d values from a %d-node list", len(vs), c.size) }
+        })
+    }
+}
+
+// TestAcyclicInputAssumed records the contract rather than testing it.
+func TestAcyclicInputAssumed(t *testing.T) {
+    t.Skip("only the methods in TestCycleSafeMethods may be called on a cyclic list; " +
+        "everything else that walks to the end (ToSlice, Do, Iterator, Copy, Equal, DiffAgainst, Clear, " +
+        "the transforms and SumRecursive) assumes the chain ends in nil and loops or overflows on a cycle")
+}
@@ -0,0 +1,118 @@
+package main
+
+import "fmt"
+
+// These examples are executable documentation: go test checks each Output
+// block, and each one shows an edge case that submissions commonly get wrong.
+
+func ExampleNew() {
+    l := New()
+    f, ok := l.Front()
+    fmt.Println(l.Len(), l.IsEmpty(), f, ok)
+    // Output: 0 true 0 false
+}
+
+func ExampleLinkedList_PushBack() {
+    l := New()
+    l.PushBack(1)
+    l.PushBack(2)
+    b, _ := l.Back()
+    fmt.Println(l.ToSlice(), b)
+    // Output: [1 2] 2
+}
+
+func ExampleLinkedList_PushFront() {
+    // Pushing to the front of an empty list makes that node the back too.
+    l := New()
+    l.PushFront(7)
+    f, _ := l.Front()
+    b, _ := l.Back()
+    fmt.Println(f, b)
+    // Output: 7 7
+}
+
+func ExampleLinkedList_PopFront() {
+    // Popping the last node must reset the back as well: the next push
+    // becomes both front and back.
+    l := New()
+    l.PushBack(5)
+    ok, v := l.PopFront()
+    l.PushBack(6)
+    b, _ := l.Back()
+    fmt.Println(ok, v, l.ToSlice(), b)
+    ok, v = New().PopFront()
+    fmt.Println(ok, v)
+    // Output:
+    // true 5 [6] 6
+    // false 0
+}
+
+func ExampleLinkedList_InsertAt() {
+    // Index Len appends; anything past it is rejected and leaves the list alone.
+    l := New()
+    l.PushBack(1)
+    l.PushBack(3)
+    fmt.Println(l.InsertAt(1, 2), l.InsertAt(l.Len(), 4), l.InsertAt(9, 5))
+    b, _ := l.Back()
+    fmt.Println(l.ToSlice(), b)
+    // Output:
+    // true true false
+    // [1 2 3 4] 4
+}
+
+func ExampleLinkedList_RemoveAt() {
+    // Removing the tail moves the back to the new last node.
+    l := BuildFromRange(1, 4, 1)
+    fmt.Println(l.RemoveAt(l.Len()-1), l.RemoveAt(l.Len()))
+    b, _ := l.Back()
+    fmt.Println(l.ToSlice(), b)
+    // Output:
+    // true false
+    // [1 2] 2
+}
+
+func ExampleLinkedList_Clear() {
+    l := BuildFromRange(0, 3, 1)
+    l.Clear()
+    l.PushBack(9)
+    fmt.Println(l.ToSlice(), l.Len())
+    // Output: [9] 1
+}
+
+func ExampleLinkedList_ToSlice() {
+    // An empty list gives an empty, non-nil slice.
+    vs := New().ToSlice()
+    fmt.Println(vs, vs == nil)
+    // Output: [] false
+}
+
+func ExampleLinkedList_Copy() {
+    // The copy owns its nodes: changing one list never shows in the other.
+    a := BuildFromRange(0, 3, 1)
+    b := a.Copy()
+    a.PushBack(3)
+    b.RemoveAt(0)
+    fmt.Println(a.ToSlice(), b.ToSlice())
+    // Output: [0 1 2 3] [1 2]
+}
+
+func ExampleMoveFrom() {
+    // MoveFrom takes the nodes and leaves the source empty but usable.
+    src := BuildFromRange(1, 4, 1)
+    dst := MoveFrom(src)
+    fmt.Println(dst.ToSlice(), src.ToSlice(), src.Len())
+    src.PushBack(8)
+    fmt.Println(dst.ToSlice(), src.ToSlice())
+    // Output:
+    // [1 2 3] [] 0
+    // [1 2 3] [8]
+}
+
+func ExampleLinkedList_MoveAssignFrom() {
+    // The destination's old contents are dropped, not merged.
+    dst := BuildFromRange(0, 2, 1)
+    src := BuildFromRange(5, 7, 1)
+    dst.MoveAssignFrom(src)
+    fmt.Println(dst.ToSlice(), src.ToSlice())
+    // Output: [5 6] []
+}
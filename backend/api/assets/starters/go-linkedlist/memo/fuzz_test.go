@@ -0,0 +1,72 @@
+package main
+
+import (
+    "fmt"
+    "testing"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/internal/opgen"
+)
+
+// decodeOps reads fuzz input as (op, idx, val) byte triples. idx is signed so
+// negative indices are exercised; a trailing partial triple is ignored.
+func decodeOps(data []byte) []op {
+    ops := make([]op, 0, len(data)/3)
+    for i := 0; i+2 < len(data); i += 3 {
+        ops = append(ops, op{kind: opKind(data[i]) % numOpKinds, idx: int(int8(data[i+1])), val: int(data[i+2])})
+    }
+    return ops
+}
+
+func encodeOps(ops []op) []byte {
+    data := make([]byte, 0, 3*len(ops))
+    for _, o := range ops { data = append(data, byte(o.kind), byte(int8(o.idx)), byte(o.val)) }
+    return data
+}
+
+// invariantErr checks that size matches the reachable node count and that
+// tail is the last reachable node.
+func invariantErr(l *LinkedList) error {
+    count := 0
+    var last *node
+    for n := l.head; n != nil; n = n.next {
+        last = n
+        count++
+        if count > l.size+1 { return fmt.Errorf("more nodes reachable than size %d (cycle?)", l.size) }
+    }
+    if count != l.size { return fmt.Errorf("size = %d but %d nodes are reachable", l.size, count) }
+    if l.tail != last { return fmt.Errorf("tail does not point at the last node") }
+    if l.tail != nil && l.tail.next != nil { return fmt.Errorf("tail.next is not nil") }
+    return nil
+}
+
+// FuzzInsertRemove applies arbitrary op sequences and checks the structural
+// invariants and the slice model after every step. memo/ is a module of its
+// own (see tools/modinit), so from the starter root:
+//
+//	cd memo && go test -fuzz=FuzzInsertRemove -fuzztime=30s .
+func FuzzInsertRemove(f *testing.F) {
+    seeds := append([][]op{
+        {{opInsertAt, 0, 9}},
+        {{opInsertAt, 1, 9}},
+        {{opPushBack, 0, 1}, {opPushBack, 0, 2}, {opPushBack, 0, 3}, {opInsertAt, 3, 9}, {opInsertAt, 5, 9}},
+        {{opPushBack, 0, 1}, {opPushBack, 0, 2}, {opInsertAt, -1, 9}, {opRemoveAt, -1, 0}},
+        {{opPushBack, 0, 1}, {opPushBack, 0, 2}, {opPushBack, 0, 3}, {opRemoveAt, 2, 0}, {opPushBack, 0, 4}},
+        {{opPushBack, 0, 1}, {opRemoveAt, 0, 0}, {opRemoveAt, 0, 0}, {opPushBack, 0, 5}, {opInsertAt, 1, 6}},
+        {{opPushBack, 0, 7}, {opPopFront, 0, 0}, {opPushBack, 0, 99}},
+    }, regressions...)
+    for seed := int64(1); seed <= 8; seed++ { seeds = append(seeds, randomOps(opgen.New(seed), 32)) }
+    for _, ops := range seeds { f.Add(encodeOps(ops)) }
+
+    f.Fuzz(func(t *testing.T, data []byte) {
+        ops := decodeOps(data)
+        l, model := New(), []int{}
+        for i, o := range ops {
+            var ok bool
+            model, ok = applyModel(l, model, o)
+            if err := invariantErr(l); err != nil { t.Fatalf("after op %d %s: %v\nops: %s", i, o, err, formatOps(ops[:i+1])) }
+            if !ok || !agrees(l, model) {
+                t.Fatalf("after op %d %s: list %v, model %v\nops: %s", i, o, l.ToSlice(), model, formatOps(ops[:i+1]))
+            }
+        }
+    })
+}
@@ -0,0 +1,70 @@
+// Package opgen generates the seeded random list operation sequences shared
+// by the memo's model tests, fuzz corpus seeding and stress test, so they all
+// draw from one distribution.
+package opgen
+
+import "math/rand"
+
+// Kind is a list operation.
+type Kind int
+
+const (
+    PushFront Kind = iota
+    PushBack
+    PopFront
+    InsertAt
+    RemoveAt
+    Clear
+    NumKinds
+)
+
+// Op is one operation; Idx is used by InsertAt and RemoveAt, Val by the
+// inserting kinds.
+type Op struct {
+    Kind     Kind
+    Idx, Val int
+}
+
+// InvalidRate is the share of InsertAt and RemoveAt ops given an out-of-range
+// index on purpose; the rest get a valid one whenever the list allows it.
+const InvalidRate = 0.1
+
+// Gen produces a deterministic op sequence for its seed. It tracks the length
+// the list would have after each op so it can aim indices at it.
+type Gen struct {
+    r    *rand.Rand
+    size int
+}
+
+func New(seed int64) *Gen { return &Gen{r: rand.New(rand.NewSource(seed))} }
+
+// Size is the list length after the ops generated so far.
+func (g *Gen) Size() int { return g.size }
+
+func (g *Gen) Next() Op {
+    o := Op{Kind: Kind(g.r.Intn(int(NumKinds))), Val: g.r.Intn(1000)}
+    if o.Kind == Clear && g.r.Intn(4) != 0 { o.Kind = PushBack } // keep lists from staying tiny
+    switch o.Kind {
+    case PushFront, PushBack: g.size++
+    case PopFront: if g.size > 0 { g.size-- }
+    case InsertAt:
+        o.Idx = g.index(g.size + 1)
+        if o.Idx >= 0 && o.Idx <= g.size { g.size++ }
+    case RemoveAt:
+        o.Idx = g.index(g.size)
+        if o.Idx >= 0 && o.Idx < g.size { g.size-- }
+    case Clear: g.size = 0
+    }
+    if o.Kind != PushFront && o.Kind != PushBack && o.Kind != InsertAt { o.Val = 0 }
+    return o
+}
+
+// index returns a valid index below n, or with probability InvalidRate (and
+// always when n is 0) one just outside the range: negative or n and above.
+func (g *Gen) index(n int) int {
+    if n == 0 || g.r.Float64() < InvalidRate {
+        if g.r.Intn(2) == 0 { return -1 - g.r.Intn(2) }
+        return n + g.r.Intn(2)
+    }
+    return g.r.Intn(n)
+}
@@ -0,0 +1,105 @@
+package main
+
+import (
+    "reflect"
+    "runtime"
+    "testing"
+    "time"
+)
+
+func iterate(it *Iterator) []int {
+    vs := []int{}
+    for it.Next() { vs = append(vs, it.Value()) }
+    return vs
+}
+
+func TestIterator(t *testing.T) {
+    for _, seed := range [][]int{{}, {1}, {1, 2, 3}} {
+        l := fromSlice(seed)
+        it := l.Iterator()
+        if v := it.Value(); v != 0 { t.Fatalf("Value() before Next = %d, want 0", v) }
+        if got := iterate(it); !reflect.DeepEqual(got, seed) { t.Fatalf("iterated %v, want %v", got, seed) }
+        if it.Next() || it.Value() != 0 { t.Fatalf("iterator over %v: Next or Value after the end", seed) }
+        checkList(t, l, seed)
+    }
+}
+
+// TestIteratorAfterClear clears and refills the list during and after a
+// walk: the iterator must end without panicking or yielding values from the
+// discarded chain. One that has not started yet sees the refilled list.
+func TestIteratorAfterClear(t *testing.T) {
+    for _, steps := range []int{0, 1, 2, 3} {
+        l := fromSlice([]int{1, 2, 3})
+        it := l.Iterator()
+        for i := 0; i < steps; i++ { it.Next() }
+        l.Clear()
+        l.PushBack(9)
+        want := []int{}
+        if steps == 0 { want = []int{9} }
+        if got := iterate(it); !reflect.DeepEqual(got, want) { t.Fatalf("after %d steps and Clear: iterator yielded %v, want %v", steps, got, want) }
+    }
+}
+
+// nodeProbe sets a finalizer on every node of l; each collected node sends
+// its value on the returned channel.
+func nodeProbe(l *LinkedList) <-chan int {
+    ch := make(chan int, l.Len())
+    for _, n := range l.nodes() { runtime.SetFinalizer(n, func(n *node) { ch <- n.val }) }
+    return ch
+}
+
+// collected runs the GC until want nodes have been finalized or a deadline
+// passes. A finalized node's successor is only finalized in a later cycle,
+// so a chain of k nodes needs about k cycles.
+func collected(ch <-chan int, want int) map[int]bool {
+    freed := map[int]bool{}
+    for deadline := time.Now().Add(5 * time.Second); len(freed) < want && time.Now().Before(deadline); {
+        runtime.GC()
+        for drained := false; !drained; {
+            select {
+            case v := <-ch: freed[v] = true
+            default: drained = true
+            }
+        }
+    }
+    return freed
+}
+
+// TestIteratorRetention checks what an iterator parked on value 49 of 0..99
+// keeps alive. The nodes it has passed must be collectable once the list
+// lets go of them, and after Clear it may pin only its current node.
+func TestIteratorRetention(t *testing.T) {
+    cases := []struct {
+        name  string
+        after func(l *LinkedList) *LinkedList // returns what the test keeps alive
+        freed func(v int) bool
+    }{
+        {"list dropped", func(l *LinkedList) *LinkedList { return nil }, func(v int) bool { return v < 49 }},
+        {"front popped", func(l *LinkedList) *LinkedList {
+            for i := 0; i < 49; i++ { l.PopFront() }
+            return l
+        }, func(v int) bool { return v < 49 }},
+        {"cleared", func(l *LinkedList) *LinkedList { l.Clear(); return l }, func(v int) bool { return v != 49 }},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            l := BuildFromRange(0, 100, 1)
+            probe := nodeProbe(l)
+            it := l.Iterator()
+            for i := 0; i < 50; i++ { it.Next() }
+            kept := c.after(l)
+            l = nil
+            want := 0
+            for v := 0; v < 100; v++ {
+                if c.freed(v) { want++ }
+            }
+            freed := collected(probe, want)
+            for v := 0; v < 100; v++ {
+                if c.freed(v) && !freed[v] { t.Errorf("node %d is still reachable", v) }
+                if !c.freed(v) && freed[v] { t.Errorf("node %d was collected while in use", v) }
+            }
+            if it.Value() != 49 { t.Fatalf("iterator moved: Value() = %d", it.Value()) }
+            runtime.KeepAlive(kept)
+        })
+    }
+}
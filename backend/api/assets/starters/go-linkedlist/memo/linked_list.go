@@ -1,20 +1,66 @@
 package main
 
+import (
+    "encoding/json"
+    "errors"
+    "sort"
+    "strconv"
+    "sync"
+)
+
 type node struct {
     val  int
     next *node
 }
 
+// LinkedList is a singly linked list of ints. The zero value is an empty
+// list; head and tail are nil exactly when size is 0, and sum is the
+// checksum of the values (see Checksum).
 type LinkedList struct {
     head *node
     tail *node
     size int
+    sum  uint64
+}
+
+// checksumOf is what each value adds to a list's checksum: v scrambled by
+// the SplitMix64 finaliser, so that lists whose plain sums agree still get
+// different checksums. It is a variable so tests can force collisions.
+var checksumOf = func(v int) uint64 {
+    x := uint64(v) + 0x9e3779b97f4a7c15
+    x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+    x = (x ^ x>>27) * 0x94d049bb133111eb
+    return x ^ x>>31
 }
 
+// ListAPI is declared in list_api.go, a copy of the driver's. This assertion
+// makes a changed signature a compile error here, naming ListAPI and the
+// method, rather than a failed build of the driver; keep it as it is.
+var _ ListAPI = (*LinkedList)(nil)
+
+// New returns an empty list.
 func New() *LinkedList { return &LinkedList{} }
+
+// Len returns the number of values in O(1).
 func (l *LinkedList) Len() int { return l.size }
+
+// IsEmpty reports whether the list holds no values.
 func (l *LinkedList) IsEmpty() bool { return l.size == 0 }
 
+// BuildFromRange returns a list of start, start+step, ... stopping before
+// end, like a half-open range. A negative step counts down; a step of 0, or
+// one that moves away from end, gives an empty list.
+func BuildFromRange(start, end, step int) *LinkedList {
+    l := New()
+    if step > 0 {
+        for v := start; v < end; v += step { l.PushBack(v) }
+    } else if step < 0 {
+        for v := start; v > end; v += step { l.PushBack(v) }
+    }
+    return l
+}
+
+// Clear removes every value, leaving an empty list that can be reused.
 func (l *LinkedList) Clear() {
     for l.head != nil {
         n := l.head
@@ -22,41 +68,55 @@ func (l *LinkedList) Clear() {
         n.next = nil
     }
     l.tail = nil
-    l.size = 0
+    l.size, l.sum = 0, 0
 }
 
+// PushFront inserts v before the first value in O(1).
 func (l *LinkedList) PushFront(v int) {
     n := &node{val: v, next: l.head}
     l.head = n
     if l.tail == nil { l.tail = n }
     l.size++
+    l.sum += checksumOf(v)
 }
 
+// PushBack appends v after the last value in O(1).
 func (l *LinkedList) PushBack(v int) {
     n := &node{val: v}
     if l.tail == nil { l.head, l.tail = n, n } else { l.tail.next = n; l.tail = n }
     l.size++
+    l.sum += checksumOf(v)
 }
 
+// PopFront removes the first value and returns true and that value, or
+// (false, 0) when the list is empty.
 func (l *LinkedList) PopFront() (bool, int) {
     if l.head == nil { return false, 0 }
     n := l.head
     l.head = n.next
     if l.head == nil { l.tail = nil }
     l.size--
+    l.sum -= checksumOf(n.val)
     return true, n.val
 }
 
+// Front returns the first value and true, or (0, false) when the list is
+// empty.
 func (l *LinkedList) Front() (int, bool) {
     if l.head == nil { return 0, false }
     return l.head.val, true
 }
 
+// Back returns the last value and true in O(1), or (0, false) when the list
+// is empty.
 func (l *LinkedList) Back() (int, bool) {
     if l.tail == nil { return 0, false }
     return l.tail.val, true
 }
 
+// InsertAt inserts v so that it ends up at index idx and reports true.
+// idx may equal Len, which appends; a negative idx or one past Len leaves
+// the list unchanged and reports false.
 func (l *LinkedList) InsertAt(idx int, v int) bool {
     if idx < 0 || idx > l.size { return false }
     if idx == 0 { l.PushFront(v); return true }
@@ -66,9 +126,12 @@ func (l *LinkedList) InsertAt(idx int, v int) bool {
     n := &node{val: v, next: prev.next}
     prev.next = n
     l.size++
+    l.sum += checksumOf(v)
     return true
 }
 
+// RemoveAt removes the value at index idx and reports true, or reports
+// false and leaves the list unchanged when idx is outside [0, Len).
 func (l *LinkedList) RemoveAt(idx int) bool {
     if idx < 0 || idx >= l.size { return false }
     if idx == 0 {
@@ -80,31 +143,745 @@ func (l *LinkedList) RemoveAt(idx int) bool {
     prev.next = victim.next
     if victim == l.tail { l.tail = prev }
     l.size--
+    l.sum -= checksumOf(victim.val)
     return true
 }
 
+// RemoveLast removes the last node holding v and reports whether there was
+// one. A single pass remembers the predecessor of the latest match.
+func (l *LinkedList) RemoveLast(v int) bool {
+    var prev, matchPrev *node
+    found := false
+    for n := l.head; n != nil; prev, n = n, n.next {
+        if n.val == v { matchPrev, found = prev, true }
+    }
+    if !found { return false }
+    if matchPrev == nil { ok, _ := l.PopFront(); return ok }
+    victim := matchPrev.next
+    matchPrev.next = victim.next
+    if victim == l.tail { l.tail = matchPrev }
+    l.size--
+    l.sum -= checksumOf(victim.val)
+    return true
+}
+
+// EnforceMaxLength trims the list to at most n values, dropping the excess
+// from the front when dropFront is true and from the back otherwise. A
+// negative n empties the list; a list no longer than n is left unchanged.
+func (l *LinkedList) EnforceMaxLength(n int, dropFront bool) {
+    if n <= 0 { l.Clear(); return }
+    if l.size <= n { return }
+    if dropFront {
+        for l.size > n { l.PopFront() }
+        return
+    }
+    last := l.head
+    for i := 1; i < n; i++ { last = last.next }
+    for d := last.next; d != nil; d = d.next { l.sum -= checksumOf(d.val) }
+    last.next = nil
+    l.tail = last
+    l.size = n
+}
+
+// ToSlice returns the values from front to back in a new slice; an empty
+// list gives an empty, non-nil slice.
 func (l *LinkedList) ToSlice() []int {
     out := make([]int, 0, l.size)
     for n := l.head; n != nil; n = n.next { out = append(out, n.val) }
     return out
 }
 
+// Do calls fn with each value from front to back until fn returns false. It
+// allocates nothing, unlike ToSlice.
+func (l *LinkedList) Do(fn func(int) bool) {
+    for n := l.head; n != nil && fn(n.val); n = n.next {}
+}
+
+// Iterator walks a list one value at a time. Before the first Next it holds
+// the list; after that only its current node, so nodes it has passed can be
+// collected once nothing else refers to them. Clear unlinks every node, so
+// an iterator over a cleared list simply ends.
+type Iterator struct {
+    l   *LinkedList // until the first Next
+    cur *node
+}
+
+// Iterator returns an iterator positioned before the first value.
+func (l *LinkedList) Iterator() *Iterator { return &Iterator{l: l} }
+
+// Next moves to the next value and reports whether there is one.
+func (it *Iterator) Next() bool {
+    if it.l != nil {
+        it.cur, it.l = it.l.head, nil
+    } else if it.cur != nil {
+        it.cur = it.cur.next
+    }
+    return it.cur != nil
+}
+
+// Value returns the current value: 0 before the first Next or after the end.
+func (it *Iterator) Value() int {
+    if it.cur == nil { return 0 }
+    return it.cur.val
+}
+
+// ToSliceCapped returns at most max values from the front (none when max is
+// negative) and reports whether more were left out. It stops after max
+// values even if the list is corrupted into a cycle.
+func (l *LinkedList) ToSliceCapped(max int) ([]int, bool) {
+    if max < 0 { max = 0 }
+    n := l.size
+    if n > max { n = max }
+    out := make([]int, 0, n)
+    for cur := l.head; cur != nil && len(out) < n; cur = cur.next { out = append(out, cur.val) }
+    return out, l.size > max
+}
+
+// PeekN returns copies of the first n values (all of them when n exceeds Len)
+// without changing the list.
+func (l *LinkedList) PeekN(n int) []int {
+    vs, _ := l.ToSliceCapped(n)
+    return vs
+}
+
+// AsStringSlice formats each value with fmtFn, or strconv.Itoa when fmtFn is nil.
+func (l *LinkedList) AsStringSlice(fmtFn func(int) string) []string {
+    if fmtFn == nil { fmtFn = strconv.Itoa }
+    out := make([]string, 0, l.size)
+    for n := l.head; n != nil; n = n.next { out = append(out, fmtFn(n.val)) }
+    return out
+}
+
+// Copy returns a new list with the same values that shares no nodes with l.
 func (l *LinkedList) Copy() *LinkedList {
     dst := New()
     for n := l.head; n != nil; n = n.next { dst.PushBack(n.val) }
     return dst
 }
 
+// Tee returns n independent copies of l, or nil when n <= 0.
+func (l *LinkedList) Tee(n int) []*LinkedList {
+    if n <= 0 { return nil }
+    copies := make([]*LinkedList, n)
+    for i := range copies { copies[i] = l.Copy() }
+    return copies
+}
+
+// CopyReversed returns a new list with l's values in reverse order. l is
+// not modified.
+func (l *LinkedList) CopyReversed() *LinkedList {
+    dst := New()
+    for n := l.head; n != nil; n = n.next { dst.PushFront(n.val) }
+    return dst
+}
+
+// Frequencies returns each distinct value in ascending order and, at the
+// same index, how many times it occurs. An empty list gives two empty slices.
+func (l *LinkedList) Frequencies() (values []int, counts []int) {
+    seen := make(map[int]int)
+    for n := l.head; n != nil; n = n.next { seen[n.val]++ }
+    values = make([]int, 0, len(seen))
+    for v := range seen { values = append(values, v) }
+    sort.Ints(values)
+    counts = make([]int, len(values))
+    for i, v := range values { counts[i] = seen[v] }
+    return values, counts
+}
+
+// CountValuesExceeding returns how many values are strictly greater than
+// threshold, in one pass over the list.
+func (l *LinkedList) CountValuesExceeding(threshold int) int {
+    count := 0
+    for n := l.head; n != nil; n = n.next {
+        if n.val > threshold { count++ }
+    }
+    return count
+}
+
+// SumValuesExceeding returns the sum of the values strictly greater than
+// threshold, in one pass over the list; 0 when there are none.
+func (l *LinkedList) SumValuesExceeding(threshold int) int {
+    sum := 0
+    for n := l.head; n != nil; n = n.next {
+        if n.val > threshold { sum += n.val }
+    }
+    return sum
+}
+
+// IsSorted reports whether the values are in non-decreasing order, in one
+// pass. Empty and single-value lists are sorted.
+func (l *LinkedList) IsSorted() bool {
+    for n := l.head; n != nil && n.next != nil; n = n.next {
+        if n.val > n.next.val { return false }
+    }
+    return true
+}
+
+// IsSortedDesc reports whether the values are in non-increasing order, in
+// one pass. Empty and single-value lists are sorted.
+func (l *LinkedList) IsSortedDesc() bool {
+    for n := l.head; n != nil && n.next != nil; n = n.next {
+        if n.val < n.next.val { return false }
+    }
+    return true
+}
+
+// MaxGap returns the largest difference between neighbouring values once
+// they are sorted, with true: [3 6 9 1] sorts to [1 3 6 9] and gives 3. It
+// sorts a copy, so the list keeps its order. A list of fewer than two
+// values has no gap: 0, false.
+func (l *LinkedList) MaxGap() (int, bool) {
+    if l.size < 2 { return 0, false }
+    vs := l.ToSlice()
+    sort.Ints(vs)
+    gap := 0
+    for i := 1; i < len(vs); i++ {
+        if d := vs[i] - vs[i-1]; d > gap { gap = d }
+    }
+    return gap, true
+}
+
+// SegmentSums splits the list into consecutive segments of the given lengths
+// and returns the sum of each, with true. It returns (nil, false) when a
+// length is negative or the lengths do not add up to Len. A zero length
+// gives a segment summing to 0, and an empty list with no lengths gives an
+// empty slice.
+func (l *LinkedList) SegmentSums(segmentLengths []int) ([]int, bool) {
+    total := 0
+    for _, n := range segmentLengths {
+        if n < 0 { return nil, false }
+        total += n
+    }
+    if total != l.size { return nil, false }
+    sums := make([]int, len(segmentLengths))
+    n := l.head
+    for i, length := range segmentLengths {
+        for j := 0; j < length; j++ { sums[i] += n.val; n = n.next }
+    }
+    return sums, true
+}
+
+// ScanLeft returns a new list of the running accumulator: element i is
+// fn applied across init and the first i+1 values. l is not modified.
+func (l *LinkedList) ScanLeft(init int, fn func(acc, v int) int) *LinkedList {
+    dst := New()
+    acc := init
+    for n := l.head; n != nil; n = n.next {
+        acc = fn(acc, n.val)
+        dst.PushBack(acc)
+    }
+    return dst
+}
+
+// MergeAlternating returns a new list taking values alternately from l and
+// other, starting with l; once either runs out the rest of the other follows.
+// Neither input is modified.
+func (l *LinkedList) MergeAlternating(other *LinkedList) *LinkedList {
+    merged := New()
+    a, b := l.head, other.head
+    for a != nil || b != nil {
+        if a != nil { merged.PushBack(a.val); a = a.next }
+        if b != nil { merged.PushBack(b.val); b = b.next }
+    }
+    return merged
+}
+
+// Deinterleave splits l into two new lists, the values at even positions
+// (0, 2, ...) and those at odd positions, each in their original order; it
+// undoes MergeAlternating of two lists of equal length. l is not modified.
+func (l *LinkedList) Deinterleave() (evenPos, oddPos *LinkedList) {
+    evenPos, oddPos = New(), New()
+    even := true
+    for n := l.head; n != nil; n = n.next {
+        if even { evenPos.PushBack(n.val) } else { oddPos.PushBack(n.val) }
+        even = !even
+    }
+    return evenPos, oddPos
+}
+
+// ToPairs returns each pair of adjacent values, front to back: [1 2 3]
+// gives [[1 2] [2 3]]. A list shorter than 2 gives an empty, non-nil slice.
+func (l *LinkedList) ToPairs() [][2]int {
+    pairs := [][2]int{}
+    for n := l.head; n != nil && n.next != nil; n = n.next { pairs = append(pairs, [2]int{n.val, n.next.val}) }
+    return pairs
+}
+
+// BucketBy groups the values by key(v) into new lists, keeping their original
+// order within each bucket. l is not modified.
+func (l *LinkedList) BucketBy(key func(int) int) map[int]*LinkedList {
+    buckets := map[int]*LinkedList{}
+    for n := l.head; n != nil; n = n.next {
+        k := key(n.val)
+        if buckets[k] == nil { buckets[k] = New() }
+        buckets[k].PushBack(n.val)
+    }
+    return buckets
+}
+
+// ReplaceAll changes every value equal to old into new and returns how
+// many it changed.
+func (l *LinkedList) ReplaceAll(old, new int) int {
+    count := 0
+    for n := l.head; n != nil; n = n.next {
+        if n.val == old { l.set(n, new); count++ }
+    }
+    return count
+}
+
+// ReplaceFirst changes the first value equal to old into new and reports
+// whether there was one.
+func (l *LinkedList) ReplaceFirst(old, new int) bool {
+    for n := l.head; n != nil; n = n.next {
+        if n.val == old { l.set(n, new); return true }
+    }
+    return false
+}
+
+// ApplyAt replaces the value at index idx with fn of it and reports true,
+// or reports false without calling fn when idx is outside [0, Len).
+func (l *LinkedList) ApplyAt(idx int, fn func(int) int) bool {
+    if idx < 0 || idx >= l.size { return false }
+    n := l.head
+    for i := 0; i < idx; i++ { n = n.next }
+    l.set(n, fn(n.val))
+    return true
+}
+
+// Clamp raises every value below lo to lo and lowers every value above hi to
+// hi, in place.
+func (l *LinkedList) Clamp(lo, hi int) {
+    for n := l.head; n != nil; n = n.next {
+        if n.val < lo { l.set(n, lo) } else if n.val > hi { l.set(n, hi) }
+    }
+}
+
+// NormalizeToRange rescales the values linearly in place so the smallest
+// becomes lo and the largest hi; hi may be below lo, which reverses the
+// order. Each value v becomes lo + (v-min)*(hi-lo)/(max-min), computed in
+// integers and rounded to the nearest int, with exact halves rounded away
+// from lo: [0 1 2] onto [0, 1] gives [0 1 1]. The product (v-min)*(hi-lo)
+// must fit in an int. An empty list, or one whose values are all equal, is
+// left unchanged.
+func (l *LinkedList) NormalizeToRange(lo, hi int) {
+    if l.head == nil { return }
+    min, max := l.head.val, l.head.val
+    for n := l.head.next; n != nil; n = n.next {
+        if n.val < min { min = n.val }
+        if n.val > max { max = n.val }
+    }
+    if min == max { return }
+    den := max - min
+    for n := l.head; n != nil; n = n.next {
+        num := (n.val - min) * (hi - lo)
+        q, r := num/den, num%den
+        // Go truncates toward zero, so r has num's sign; round the
+        // quotient away from zero when r is at least half of den.
+        if 2*r >= den { q++ } else if -2*r >= den { q-- }
+        l.set(n, lo+q)
+    }
+}
+
+// set changes n's value to v and keeps the checksum in step.
+func (l *LinkedList) set(n *node, v int) {
+    l.sum += checksumOf(v) - checksumOf(n.val)
+    n.val = v
+}
+
+// UniqueCounting collapses each run of equal adjacent values to its first
+// node and returns how many nodes it removed.
+func (l *LinkedList) UniqueCounting() int {
+    removed := 0
+    for n := l.head; n != nil; {
+        if n.next != nil && n.next.val == n.val {
+            l.sum -= checksumOf(n.val)
+            n.next = n.next.next
+            removed++
+            continue
+        }
+        l.tail = n
+        n = n.next
+    }
+    l.size -= removed
+    return removed
+}
+
+// RemoveIfAdjacentEqual removes both nodes of every adjacent pair holding
+// equal values, then repeats on the result until a pass removes nothing, so
+// [1 2 2 3 3 4] collapses to [1 4] and [1 2 3 3 2 4] does too. Unlike
+// UniqueCounting, no copy of a removed pair survives.
+func (l *LinkedList) RemoveIfAdjacentEqual() {
+    for changed := true; changed; {
+        changed = false
+        var prev *node
+        for n := l.head; n != nil; {
+            if n.next == nil || n.next.val != n.val {
+                prev, n = n, n.next
+                continue
+            }
+            after := n.next.next
+            if prev == nil { l.head = after } else { prev.next = after }
+            l.size -= 2
+            l.sum -= 2 * checksumOf(n.val)
+            changed = true
+            n = after
+        }
+        l.tail = prev
+    }
+}
+
+// RemoveWhereIndex removes every node whose original index satisfies pred
+// and returns how many it removed.
+func (l *LinkedList) RemoveWhereIndex(pred func(index int) bool) int {
+    removed := 0
+    var prev *node
+    for i, n := 0, l.head; n != nil; i, n = i+1, n.next {
+        if !pred(i) { prev = n; continue }
+        if prev == nil { l.head = n.next } else { prev.next = n.next }
+        l.sum -= checksumOf(n.val)
+        removed++
+    }
+    l.tail = prev
+    l.size -= removed
+    return removed
+}
+
+// InsertSortedUnique inserts v before the first larger value of an ascending
+// list and reports true, or leaves the list alone and reports false when v is
+// already present.
+func (l *LinkedList) InsertSortedUnique(v int) bool {
+    var prev *node
+    cur := l.head
+    for cur != nil && cur.val < v { prev, cur = cur, cur.next }
+    if cur != nil && cur.val == v { return false }
+    n := &node{val: v, next: cur}
+    if prev == nil { l.head = n } else { prev.next = n }
+    if cur == nil { l.tail = n }
+    l.size++
+    l.sum += checksumOf(v)
+    return true
+}
+
+// ErrNotSorted is returned by PushBackSorted when v would break the order.
+var ErrNotSorted = errors.New("value is smaller than the back of the list")
+
+// PushBackSorted appends v when the list is empty or v >= Back(), keeping an
+// ascending list ascending; otherwise it returns ErrNotSorted and leaves the
+// list unchanged.
+func (l *LinkedList) PushBackSorted(v int) error {
+    if l.tail != nil && v < l.tail.val { return ErrNotSorted }
+    l.PushBack(v)
+    return nil
+}
+
+// SwapPairs swaps each pair of adjacent nodes by relinking them, leaving an
+// odd last node in place: [1 2 3 4 5] becomes [2 1 4 3 5].
+func (l *LinkedList) SwapPairs() {
+    link := &l.head
+    for a := l.head; a != nil && a.next != nil; a = a.next {
+        b := a.next
+        a.next, b.next = b.next, a
+        *link = b
+        link = &a.next
+        if l.tail == b { l.tail = a }
+    }
+}
+
+// InterquartileTrim removes the outliers: values outside
+// [Q1 - 1.5*IQR, Q3 + 1.5*IQR]. Quartiles interpolate linearly between the
+// closest ranks of the sorted values (Q at p is at rank (n-1)*p, as in
+// numpy's default), so [1 2 3 4] has Q1 = 1.75 and Q3 = 3.25. Survivors keep
+// their order.
+func (l *LinkedList) InterquartileTrim() {
+    if l.size == 0 { return }
+    vals := l.ToSlice()
+    sorted := append([]int(nil), vals...)
+    sort.Ints(sorted)
+    quartile := func(p float64) float64 {
+        h := float64(len(sorted)-1) * p
+        i := int(h)
+        if i+1 == len(sorted) { return float64(sorted[i]) }
+        return float64(sorted[i]) + (h-float64(i))*float64(sorted[i+1]-sorted[i])
+    }
+    q1, q3 := quartile(0.25), quartile(0.75)
+    lo, hi := q1-1.5*(q3-q1), q3+1.5*(q3-q1)
+    l.RemoveWhereIndex(func(i int) bool { return float64(vals[i]) < lo || float64(vals[i]) > hi })
+}
+
+// RotateUntilSorted relinks a rotated ascending list (non-decreasing, so
+// [3 4 5 1 2] qualifies) into sorted order and returns how many left
+// rotations that took. A list no rotation can sort is left as is: 0, false.
+func (l *LinkedList) RotateUntilSorted() (rotations int, ok bool) {
+    var prev, start *node
+    idx := 0
+    for n := l.head; n != nil && n.next != nil; n = n.next {
+        idx++
+        if n.val <= n.next.val { continue }
+        if start != nil { return 0, false }
+        prev, start, rotations = n, n.next, idx
+    }
+    if start == nil { return 0, true }
+    if l.tail.val > l.head.val { return 0, false }
+    l.tail.next = l.head
+    l.head, l.tail = start, prev
+    prev.next = nil
+    return rotations, true
+}
+
+// WindowMax returns the maximum of every run of k adjacent values, front to
+// back: Len-k+1 results, or an empty slice when k <= 0 or k > Len. It runs
+// in O(n) however large k is.
+func (l *LinkedList) WindowMax(k int) []int {
+    // A deque of candidate maxima whose values decrease from front to back:
+    // each node is pushed and popped at most once.
+    maxes := []int{}
+    if k <= 0 || k > l.size { return maxes }
+    type entry struct{ idx, val int }
+    var dq []entry
+    i := 0
+    for n := l.head; n != nil; n = n.next {
+        for len(dq) > 0 && dq[len(dq)-1].val <= n.val { dq = dq[:len(dq)-1] }
+        dq = append(dq, entry{i, n.val})
+        if dq[0].idx <= i-k { dq = dq[1:] }
+        if i >= k-1 { maxes = append(maxes, dq[0].val) }
+        i++
+    }
+    return maxes
+}
+
+// SumRecursive adds the values with a recursive walk over the nodes. It is a
+// teaching reference only: recursion depth equals Len, so very long lists
+// cost a stack frame per node where a loop would run in constant space.
+func (l *LinkedList) SumRecursive() int { return sumFrom(l.head) }
+
+func sumFrom(n *node) int {
+    if n == nil { return 0 }
+    return n.val + sumFrom(n.next)
+}
+
+// CycleLength reports how many nodes form the cycle reachable from head, or
+// (0, false) when the chain ends in nil. A correct list never has a cycle;
+// this is for diagnosing corrupted ones, so it must not loop forever on one.
+func (l *LinkedList) CycleLength() (int, bool) {
+    // Floyd's slow and fast walkers meet inside the cycle, and one more lap
+    // from the meeting point counts its length.
+    slow, fast := l.head, l.head
+    for fast != nil && fast.next != nil {
+        slow, fast = slow.next, fast.next.next
+        if slow != fast { continue }
+        n := 1
+        for p := slow.next; p != slow; p = p.next { n++ }
+        return n, true
+    }
+    return 0, false
+}
+
+// GapEncode returns the first value followed by the difference between each
+// value and the one before it; DecodeGaps reverses it.
+func (l *LinkedList) GapEncode() []int {
+    gaps := make([]int, 0, l.size)
+    prev := 0
+    for n := l.head; n != nil; n = n.next {
+        gaps = append(gaps, n.val-prev)
+        prev = n.val
+    }
+    return gaps
+}
+
+// DecodeGaps rebuilds the list GapEncode produced vs from.
+func DecodeGaps(vs []int) *LinkedList {
+    l := New()
+    sum := 0
+    for _, g := range vs {
+        sum += g
+        l.PushBack(sum)
+    }
+    return l
+}
+
+// Checksum returns a hash of the values that ignores their order. Every
+// method that adds, removes or changes a value keeps it up to date, so it
+// costs O(1). Lists holding the same values have the same checksum; other
+// lists almost always differ, but may collide.
+func (l *LinkedList) Checksum() uint64 { return l.sum }
+
+// Equal reports whether l and other hold the same values in the same order.
+// Lists of different sizes or checksums are rejected in O(1) without walking
+// either one, which settles the common unequal case however long the lists
+// are. Matching checksums do not prove equality, as they may collide or the
+// values may be in another order, so the lists are then compared value by
+// value and the answer is always exact.
+func (l *LinkedList) Equal(other *LinkedList) bool {
+    if l.size != other.size || l.sum != other.sum { return false }
+    for a, b := l.head, other.head; a != nil; a, b = a.next, b.next {
+        if a.val != b.val { return false }
+    }
+    return true
+}
+
+// EqualAsSet compares the distinct values of l and other, ignoring order and
+// repeats. It also returns the values only l holds and those only other
+// holds, each sorted ascending and nil when there are none, so feedback can
+// name them.
+func (l *LinkedList) EqualAsSet(other *LinkedList) (bool, []int, []int) {
+    mine, theirs := map[int]bool{}, map[int]bool{}
+    for n := l.head; n != nil; n = n.next { mine[n.val] = true }
+    for n := other.head; n != nil; n = n.next { theirs[n.val] = true }
+    var onlyL, onlyOther []int
+    for v := range mine {
+        if !theirs[v] { onlyL = append(onlyL, v) }
+    }
+    for v := range theirs {
+        if !mine[v] { onlyOther = append(onlyOther, v) }
+    }
+    sort.Ints(onlyL)
+    sort.Ints(onlyOther)
+    return onlyL == nil && onlyOther == nil, onlyL, onlyOther
+}
+
+// Diff is one positional difference reported by DiffAgainst. Kind is
+// "mismatch" (both lists have Index, values differ), "missing" (only want has
+// it, Got is 0) or "extra" (only l has it, Want is 0).
+type Diff struct {
+    Index int    `json:"index"`
+    Got   int    `json:"got"`
+    Want  int    `json:"want"`
+    Kind  string `json:"kind"`
+}
+
+// DiffAgainst compares l with want position by position and returns every
+// difference in index order; nil means the lists are equal.
+func (l *LinkedList) DiffAgainst(want *LinkedList) []Diff {
+    var diffs []Diff
+    g, w := l.head, want.head
+    for i := 0; g != nil || w != nil; i++ {
+        switch {
+        case w == nil:
+            diffs = append(diffs, Diff{Index: i, Got: g.val, Kind: "extra"})
+        case g == nil:
+            diffs = append(diffs, Diff{Index: i, Want: w.val, Kind: "missing"})
+        case g.val != w.val:
+            diffs = append(diffs, Diff{Index: i, Got: g.val, Want: w.val, Kind: "mismatch"})
+        }
+        if g != nil { g = g.next }
+        if w != nil { w = w.next }
+    }
+    return diffs
+}
+
+// DiffJSON reports DiffAgainst(want) for the feedback service as
+// {"equal":bool,"diffs":[...]}; diffs is an empty array when equal.
+func (l *LinkedList) DiffJSON(want *LinkedList) ([]byte, error) {
+    diffs := l.DiffAgainst(want)
+    if diffs == nil { diffs = []Diff{} }
+    return json.Marshal(struct {
+        Equal bool   `json:"equal"`
+        Diffs []Diff `json:"diffs"`
+    }{len(diffs) == 0, diffs})
+}
+
+// MoveFrom returns a new list that takes over src's nodes without copying
+// them, and leaves src empty.
 func MoveFrom(src *LinkedList) *LinkedList {
     dst := New()
-    dst.head, dst.tail, dst.size = src.head, src.tail, src.size
-    src.head, src.tail, src.size = nil, nil, 0
+    dst.head, dst.tail, dst.size, dst.sum = src.head, src.tail, src.size, src.sum
+    src.head, src.tail, src.size, src.sum = nil, nil, 0, 0
     return dst
 }
 
+// MoveAssignFrom clears l, then takes over src's nodes without copying
+// them and leaves src empty.
 func (l *LinkedList) MoveAssignFrom(src *LinkedList) {
     l.Clear()
-    l.head, l.tail, l.size = src.head, src.tail, src.size
-    src.head, src.tail, src.size = nil, nil, 0
+    l.head, l.tail, l.size, l.sum = src.head, src.tail, src.size, src.sum
+    src.head, src.tail, src.size, src.sum = nil, nil, 0, 0
+}
+
+// dnode is one value of a DoublyLinkedList with links both ways.
+type dnode struct {
+    val        int
+    prev, next *dnode
+}
+
+// DoublyLinkedList is a list of ints linked in both directions, with a tail
+// pointer, so it can be walked from either end. The zero value is an empty
+// list ready to use.
+type DoublyLinkedList struct {
+    head, tail *dnode
+    size       int
 }
 
+// NewDoubly returns an empty DoublyLinkedList.
+func NewDoubly() *DoublyLinkedList { return &DoublyLinkedList{} }
+
+// Len returns the number of values in O(1).
+func (d *DoublyLinkedList) Len() int { return d.size }
+
+// PushBack appends v after the last value in O(1).
+func (d *DoublyLinkedList) PushBack(v int) { d.insertBefore(nil, v) }
+
+// InsertSorted inserts v into a list in ascending order so that it stays in
+// order, after any values equal to v. It walks in from both ends at once,
+// one step each, and inserts as soon as either side reaches v's place, so it
+// takes as many steps as v's distance from the nearer end: inserting near
+// the back is as cheap as near the front, which a singly linked list cannot
+// do. On an unsorted list v still goes in, at an unspecified index.
+func (d *DoublyLinkedList) InsertSorted(v int) {
+    if d.size == 0 { d.PushBack(v); return }
+    for f, b := d.head, d.tail; ; f, b = f.next, b.prev {
+        if f.val > v { d.insertBefore(f, v); return }
+        if b.val <= v { d.insertBefore(b.next, v); return }
+    }
+}
+
+// insertBefore links v in before at, or at the back when at is nil.
+func (d *DoublyLinkedList) insertBefore(at *dnode, v int) {
+    n := &dnode{val: v, next: at}
+    if at == nil { n.prev, d.tail = d.tail, n } else { n.prev, at.prev = at.prev, n }
+    if n.prev == nil { d.head = n } else { n.prev.next = n }
+    d.size++
+}
+
+// ToSlice returns the values from front to back, following next links; an
+// empty list gives an empty, non-nil slice.
+func (d *DoublyLinkedList) ToSlice() []int {
+    out := make([]int, 0, d.size)
+    for n := d.head; n != nil; n = n.next { out = append(out, n.val) }
+    return out
+}
+
+// ToSliceReverse returns the values from back to front, following prev
+// links, so it shows whether they agree with ToSlice.
+func (d *DoublyLinkedList) ToSliceReverse() []int {
+    out := make([]int, 0, d.size)
+    for n := d.tail; n != nil; n = n.prev { out = append(out, n.val) }
+    return out
+}
+
+
+// SafeList is a LinkedList guarded by a mutex for use from several
+// goroutines. PopFront checks and removes under one lock, so callers never
+// need the racy Front-then-PopFront pattern.
+type SafeList struct {
+    mu sync.Mutex
+    l  LinkedList
+}
+
+// NewSafeList returns an empty SafeList.
+func NewSafeList() *SafeList { return &SafeList{} }
+
+// Len returns the number of values.
+func (s *SafeList) Len() int { s.mu.Lock(); defer s.mu.Unlock(); return s.l.Len() }
+
+// PushFront inserts v before the first value.
+func (s *SafeList) PushFront(v int) { s.mu.Lock(); defer s.mu.Unlock(); s.l.PushFront(v) }
+
+// PushBack appends v after the last value.
+func (s *SafeList) PushBack(v int) { s.mu.Lock(); defer s.mu.Unlock(); s.l.PushBack(v) }
+
+// PopFront removes and returns the first value as LinkedList.PopFront does,
+// holding the lock for both the check and the removal.
+func (s *SafeList) PopFront() (bool, int) { s.mu.Lock(); defer s.mu.Unlock(); return s.l.PopFront() }
+
+// ToSlice returns a snapshot of the values from front to back.
+func (s *SafeList) ToSlice() []int { s.mu.Lock(); defer s.mu.Unlock(); return s.l.ToSlice() }
@@ -0,0 +1,313 @@
+package main
+
+import (
+    "encoding/json"
+    "fmt"
+    "math/rand"
+    "os"
+    "path/filepath"
+    "testing"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/altimpl"
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/internal/opgen"
+)
+
+// benchSizes are the list sizes each benchmark runs at; -short keeps only the
+// small one so CI can run `go test -short -bench . -benchtime 100x` cheaply.
+func benchSizes() []int {
+    if testing.Short() { return []int{1000} }
+    return []int{1000, 100000}
+}
+
+func filled(n int) *LinkedList {
+    l := New()
+    for i := 0; i < n; i++ { l.PushBack(i) }
+    return l
+}
+
+func BenchmarkPushBack(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                l := New()
+                for j := 0; j < n; j++ { l.PushBack(j) }
+            }
+        })
+    }
+}
+
+func BenchmarkPushFront(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                l := New()
+                for j := 0; j < n; j++ { l.PushFront(j) }
+            }
+        })
+    }
+}
+
+func BenchmarkPopFront(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                b.StopTimer()
+                l := filled(n)
+                b.StartTimer()
+                for !l.IsEmpty() { l.PopFront() }
+            }
+        })
+    }
+}
+
+func BenchmarkInsertAtMiddle(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            l := filled(n)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                l.InsertAt(n/2, i)
+                l.RemoveAt(n / 2)
+            }
+        })
+    }
+}
+
+func BenchmarkToSlice(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            l := filled(n)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ { _ = l.ToSlice() }
+        })
+    }
+}
+
+func BenchmarkCopy(b *testing.B) {
+    for _, n := range benchSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            l := filled(n)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ { _ = l.Copy() }
+        })
+    }
+}
+
+// exactAllocs are the operations whose budget is an exact count rather than a
+// ceiling: a PushBack that allocates no node or a ToSlice that allocates no
+// array is sharing storage it must not share, not an improvement.
+var exactAllocs = map[string]bool{"PushBack": true, "ToSlice": true}
+
+// TestAllocationRegression measures allocations per call on the hot paths
+// and compares them with testdata/alloc_budget.json: one node per insert, no
+// allocation to pop or to visit with Do, one backing array per ToSlice. Going
+// over budget fails; coming in under it is logged so the budget can be
+// tightened, except for the operations in exactAllocs. Change the file only
+// as a deliberate decision.
+func TestAllocationRegression(t *testing.T) {
+    data, err := os.ReadFile(filepath.Join("testdata", "alloc_budget.json"))
+    if err != nil { t.Fatal(err) }
+    var budget map[string]float64
+    if err := json.Unmarshal(data, &budget); err != nil { t.Fatalf("alloc_budget.json: %v", err) }
+
+    l := filled(1000)
+    drain := filled(2000)
+    sum := 0
+    ops := map[string]func(){
+        "PushBack":        func() { l.PushBack(1) },
+        "PushFront":       func() { l.PushFront(1) },
+        "PopFront":        func() { drain.PopFront() },
+        "InsertAt-middle": func() { l.InsertAt(l.Len()/2, 1) },
+        "ToSlice":         func() { _ = l.ToSlice() },
+        "Do":              func() { l.Do(func(v int) bool { sum += v; return true }) },
+    }
+    for name := range budget {
+        if ops[name] == nil { t.Errorf("alloc_budget.json: no operation %q is measured", name) }
+    }
+    for name, fn := range ops {
+        want, ok := budget[name]
+        if !ok { t.Errorf("%s: no budget in alloc_budget.json", name); continue }
+        got := testing.AllocsPerRun(1000, fn)
+        switch {
+        case got > want: t.Errorf("%s allocates %v objects per call, budget is %v", name, got, want)
+        case got < want && exactAllocs[name]: t.Errorf("%s allocates %v objects per call, want exactly %v", name, got, want)
+        case got < want: t.Logf("%s allocates %v objects per call, under its budget of %v; tighten alloc_budget.json", name, got, want)
+        }
+    }
+}
+
+// traverseSizes are the list sizes the traversal benchmarks compare at;
+// -short keeps only the smaller one.
+func traverseSizes() []int {
+    if testing.Short() { return []int{10000} }
+    return []int{10000, 1000000}
+}
+
+// BenchmarkTraverseToSlice and BenchmarkTraverseDo sum a list through each
+// API. ToSlice allocates the whole slice first; Do allocates nothing. On the
+// reference machine Do ran about 2.5x faster at 10k elements (21µs vs 53µs)
+// and about 7x at 1M (2.2ms vs 15ms, where ToSlice also allocates 8MB), so
+// prefer Do for read-only passes over large lists.
+func BenchmarkTraverseToSlice(b *testing.B) {
+    for _, n := range traverseSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            l := filled(n)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                sum := 0
+                for _, v := range l.ToSlice() { sum += v }
+                _ = sum
+            }
+        })
+    }
+}
+
+func BenchmarkTraverseDo(b *testing.B) {
+    for _, n := range traverseSizes() {
+        b.Run(fmt.Sprint(n), func(b *testing.B) {
+            l := filled(n)
+            b.ReportAllocs()
+            b.ResetTimer()
+            for i := 0; i < b.N; i++ {
+                sum := 0
+                l.Do(func(v int) bool { sum += v; return true })
+                _ = sum
+            }
+        })
+    }
+}
+
+// equalWalk is Equal without the checksum pre-check, the baseline
+// BenchmarkEqual measures it against.
+func equalWalk(a, b *LinkedList) bool {
+    if a.size != b.size { return false }
+    for x, y := a.head, b.head; x != nil; x, y = x.next, y.next {
+        if x.val != y.val { return false }
+    }
+    return true
+}
+
+// BenchmarkEqual compares unequal lists of traverseSizes elements with Equal
+// and with a walk that has no checksum pre-check. A size mismatch returns in
+// a few ns either way, and so does a value changed at the front, where the
+// walk stops at once. A value changed at the back is where the cached
+// checksum pays: Equal still returns in O(1) while the walk crosses both
+// lists in full (10k elements on the reference machine: 4ns vs 21µs). The same values in another order have equal checksums, so
+// Equal walks too, as it must.
+func BenchmarkEqual(b *testing.B) {
+    for _, n := range traverseSizes() {
+        x := filled(n)
+        shorter, first, last, swapped := filled(n-1), filled(n), filled(n), filled(n)
+        first.ApplyAt(0, func(int) int { return -1 })
+        last.ApplyAt(n-1, func(int) int { return -1 })
+        swapped.SwapPairs()
+        for _, c := range []struct {
+            name  string
+            other *LinkedList
+        }{{"size-differs", shorter}, {"differs-first", first}, {"differs-last", last}, {"reordered", swapped}} {
+            for _, eq := range []struct {
+                name string
+                fn   func(a, b *LinkedList) bool
+            }{{"checksum-first", (*LinkedList).Equal}, {"walk", equalWalk}} {
+                b.Run(fmt.Sprintf("%d/%s/%s", n, c.name, eq.name), func(b *testing.B) {
+                    for i := 0; i < b.N; i++ {
+                        if eq.fn(x, c.other) { b.Fatal("lists compared equal") }
+                    }
+                })
+            }
+        }
+    }
+}
+
+// BenchmarkImpls replays the stress workloads against the memo and the naive
+// implementations in altimpl. Next to the usual ns/op and allocs/op for a
+// whole workload it reports ns/listop, the mean cost of one list operation,
+// so the rows compare directly. The stress workloads keep the list short,
+// where shifting a slice is cheap; fifo fills it to benchFIFO elements first
+// so the O(n) front and tail operations show. memo/ is a module of its own,
+// so from the starter root:
+//
+//     cd memo && go test -run '^$' -bench Impls .
+func BenchmarkImpls(b *testing.B) {
+    const benchFIFO = 10000
+    mixed := randomOps(opgen.New(modelSeed), stressOps)
+    workloads := []struct {
+        name string
+        ops  int
+        run  func(l altimpl.List)
+    }{
+        {"mixed", stressOps, func(l altimpl.List) {
+            for _, o := range mixed { apply(l, o); l.Len() }
+        }},
+        {"push-front-remove-last", stressOps, func(l altimpl.List) {
+            for i := 0; i < stressWindow; i++ { l.PushBack(i) }
+            for i := 0; i < stressOps/2; i++ {
+                l.PushFront(stressWindow + i)
+                l.RemoveAt(l.Len() - 1)
+            }
+        }},
+        {"fifo", 2 * benchFIFO, func(l altimpl.List) {
+            for i := 0; i < benchFIFO; i++ { l.PushBack(i) }
+            for i := 0; i < benchFIFO; i++ { l.PopFront() }
+        }},
+    }
+    for _, w := range workloads {
+        for _, impl := range impls {
+            b.Run(w.name+"/"+impl.name, func(b *testing.B) {
+                b.ReportAllocs()
+                for i := 0; i < b.N; i++ { w.run(impl.new()) }
+                b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(w.ops), "ns/listop")
+            })
+        }
+    }
+}
+
+// BenchmarkArrayVsLinked times ArrayList against LinkedList on the two
+// workloads where they differ most. random-access reads n values at seeded
+// random indices: O(1) each for ArrayList.At, a walk from the head for
+// LinkedList.ApplyAt (given the identity function, so it only reads).
+// middle-insert builds a list of n values by always inserting at the middle:
+// both walk or shift about half the list per insert, but ArrayList's copy
+// is contiguous and LinkedList allocates a node each time.
+func BenchmarkArrayVsLinked(b *testing.B) {
+    same := func(v int) int { return v }
+    for _, n := range benchSizes() {
+        idx := make([]int, n)
+        r := rand.New(rand.NewSource(modelSeed))
+        for i := range idx { idx[i] = r.Intn(n) }
+        a, l := NewWithCapacity(n), filled(n)
+        for i := 0; i < n; i++ { a.PushBack(i) }
+
+        b.Run(fmt.Sprintf("random-access/%d/array", n), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                for _, j := range idx { a.At(j) }
+            }
+        })
+        b.Run(fmt.Sprintf("random-access/%d/linked", n), func(b *testing.B) {
+            for i := 0; i < b.N; i++ {
+                for _, j := range idx { l.ApplyAt(j, same) }
+            }
+        })
+        b.Run(fmt.Sprintf("middle-insert/%d/array", n), func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                a := NewWithCapacity(n)
+                for j := 0; j < n; j++ { a.InsertAt(a.Len()/2, j) }
+            }
+        })
+        b.Run(fmt.Sprintf("middle-insert/%d/linked", n), func(b *testing.B) {
+            b.ReportAllocs()
+            for i := 0; i < b.N; i++ {
+                l := New()
+                for j := 0; j < n; j++ { l.InsertAt(l.Len()/2, j) }
+            }
+        })
+    }
+}
@@ -0,0 +1,1241 @@
+package main
+
+import (
+    "bytes"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strconv"
+    "testing"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/listtest"
+)
+
+func fromSlice(vs []int) *LinkedList {
+    l := New()
+    for _, v := range vs { l.PushBack(v) }
+    return l
+}
+
+// chain shows l's nodes to listtest.AssertInvariants.
+type chain struct{ *LinkedList }
+
+func (c chain) Chain() ([]int, int, bool) {
+    var reachable []int
+    var last *node
+    for n := c.head; n != nil; n = n.next { reachable, last = append(reachable, n.val), n }
+    return reachable, c.size, c.tail == last
+}
+
+// checkList asserts the observable contents of l, that head, tail and size
+// agree with the node chain and that the cached checksum matches the values.
+func checkList(t *testing.T, l *LinkedList, want []int) {
+    t.Helper()
+    listtest.AssertInvariants(t, chain{l})
+    listtest.AssertList(t, l, want)
+    checkChecksum(t, l, want)
+}
+
+// checkEmpty is checkList for an empty list.
+func checkEmpty(t *testing.T, l *LinkedList) {
+    t.Helper()
+    listtest.AssertInvariants(t, chain{l})
+    listtest.AssertEmpty(t, l)
+    checkChecksum(t, l, nil)
+}
+
+// checkChecksum asserts that l's checksum is the one computed from scratch
+// over want, so a mutator that forgets to maintain it fails its test.
+func checkChecksum(t *testing.T, l *LinkedList, want []int) {
+    t.Helper()
+    var sum uint64
+    for _, v := range want { sum += checksumOf(v) }
+    if got := l.Checksum(); got != sum { t.Fatalf("Checksum() = %#x, want %#x for %v", got, sum, want) }
+}
+
+func TestNewIsEmpty(t *testing.T) {
+    t.Parallel()
+    l := New()
+    checkEmpty(t, l)
+}
+
+func TestBuildFromRange(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        start, end, step int
+        want             []int
+    }{
+        {0, 10, 2, []int{0, 2, 4, 6, 8}},
+        {0, 9, 3, []int{0, 3, 6}},
+        {5, 0, -2, []int{5, 3, 1}},
+        {-3, 0, 1, []int{-3, -2, -1}},
+        {0, 0, 1, []int{}},
+        {0, 5, -1, []int{}},
+        {5, 0, 1, []int{}},
+        {0, 5, 0, []int{}},
+    }
+    for _, c := range cases {
+        checkList(t, BuildFromRange(c.start, c.end, c.step), c.want)
+    }
+}
+
+func TestPushFrontBack(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        name string
+        ops  func(l *LinkedList)
+        want []int
+    }{
+        {"push-front-one", func(l *LinkedList) { l.PushFront(1) }, []int{1}},
+        {"push-back-one", func(l *LinkedList) { l.PushBack(1) }, []int{1}},
+        {"push-front-many", func(l *LinkedList) { l.PushFront(1); l.PushFront(2); l.PushFront(3) }, []int{3, 2, 1}},
+        {"push-back-many", func(l *LinkedList) { l.PushBack(1); l.PushBack(2); l.PushBack(3) }, []int{1, 2, 3}},
+        {"mixed", func(l *LinkedList) { l.PushFront(2); l.PushBack(5); l.PushFront(1) }, []int{1, 2, 5}},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            l := New()
+            c.ops(l)
+            checkList(t, l, c.want)
+        })
+    }
+}
+
+func TestPopFront(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        name   string
+        seed   []int
+        ok     bool
+        popped int
+        want   []int
+    }{
+        {"empty", nil, false, 0, []int{}},
+        {"single", []int{7}, true, 7, []int{}},
+        {"many", []int{1, 2, 3}, true, 1, []int{2, 3}},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            l := fromSlice(c.seed)
+            ok, v := l.PopFront()
+            if ok != c.ok || v != c.popped { t.Fatalf("PopFront() = (%t,%d), want (%t,%d)", ok, v, c.ok, c.popped) }
+            checkList(t, l, c.want)
+        })
+    }
+}
+
+func TestPopLastThenPush(t *testing.T) {
+    t.Parallel()
+    l := fromSlice([]int{7})
+    l.PopFront()
+    checkEmpty(t, l)
+    l.PushBack(99)
+    checkList(t, l, []int{99})
+    l.PopFront()
+    l.PushFront(5)
+    checkList(t, l, []int{5})
+}
+
+func TestInsertAt(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        name string
+        seed []int
+        idx  int
+        ok   bool
+        want []int
+    }{
+        {"empty-at-0", nil, 0, true, []int{9}},
+        {"empty-at-1", nil, 1, false, []int{}},
+        {"negative", []int{1, 2}, -1, false, []int{1, 2}},
+        {"head", []int{1, 2, 3}, 0, true, []int{9, 1, 2, 3}},
+        {"second", []int{1, 2, 3}, 1, true, []int{1, 9, 2, 3}},
+        {"middle", []int{1, 2, 3, 4}, 2, true, []int{1, 2, 9, 3, 4}},
+        {"before-tail", []int{1, 2, 3}, 2, true, []int{1, 2, 9, 3}},
+        {"append-at-len", []int{1, 2, 3}, 3, true, []int{1, 2, 3, 9}},
+        {"past-len", []int{1, 2, 3}, 4, false, []int{1, 2, 3}},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            l := fromSlice(c.seed)
+            if ok := l.InsertAt(c.idx, 9); ok != c.ok { t.Fatalf("InsertAt(%d) = %t, want %t", c.idx, ok, c.ok) }
+            checkList(t, l, c.want)
+        })
+    }
+}
+
+func TestRemoveAt(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        name string
+        seed []int
+        idx  int
+        ok   bool
+        want []int
+    }{
+        {"empty", nil, 0, false, []int{}},
+        {"negative", []int{1, 2}, -1, false, []int{1, 2}},
+        {"only", []int{1}, 0, true, []int{}},
+        {"head", []int{1, 2, 3}, 0, true, []int{2, 3}},
+        {"middle", []int{1, 2, 3}, 1, true, []int{1, 3}},
+        {"tail", []int{1, 2, 3}, 2, true, []int{1, 2}},
+        {"at-len", []int{1, 2, 3}, 3, false, []int{1, 2, 3}},
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            l := fromSlice(c.seed)
+            if ok := l.RemoveAt(c.idx); ok != c.ok { t.Fatalf("RemoveAt(%d) = %t, want %t", c.idx, ok, c.ok) }
+            checkList(t, l, c.want)
+        })
+    }
+}
+
+// Removing the tail and then pushing is the sequence that historically left a
+// stale tail pointer behind.
+func TestRemoveTailThenPush(t *testing.T) {
+    t.Parallel()
+    l := fromSlice([]int{1, 2, 3})
+    l.RemoveAt(l.Len() - 1)
+    l.PushBack(4)
+    checkList(t, l, []int{1, 2, 4})
+    l.RemoveAt(l.Len() - 1)
+    l.RemoveAt(l.Len() - 1)
+    l.RemoveAt(l.Len() - 1)
+    checkEmpty(t, l)
+    l.PushBack(5)
+    checkList(t, l, []int{5})
+    l.InsertAt(l.Len(), 6)
+    checkList(t, l, []int{5, 6})
+}
+
+func TestRemoveLast(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        v    int
+        ok   bool
+        want []int
+    }{
+        {[]int{}, 2, false, []int{}},
+        {[]int{1, 3}, 2, false, []int{1, 3}},
+        {[]int{2}, 2, true, []int{}},
+        {[]int{1, 2, 3, 2, 4}, 2, true, []int{1, 2, 3, 4}},
+        {[]int{2, 1, 3}, 2, true, []int{1, 3}},
+        {[]int{1, 2, 3, 2}, 2, true, []int{1, 2, 3}},
+        {[]int{2, 2}, 2, true, []int{2}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if ok := l.RemoveLast(c.v); ok != c.ok { t.Fatalf("RemoveLast(%d) on %v = %t, want %t", c.v, c.seed, ok, c.ok) }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(append([]int{}, c.want...), 100))
+    }
+}
+
+func TestEnforceMaxLength(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed      []int
+        n         int
+        dropFront bool
+        want      []int
+    }{
+        {[]int{1, 2, 3, 4, 5}, 3, true, []int{3, 4, 5}},
+        {[]int{1, 2, 3, 4, 5}, 3, false, []int{1, 2, 3}},
+        {[]int{1, 2, 3}, 3, false, []int{1, 2, 3}},
+        {[]int{1, 2}, 5, true, []int{1, 2}},
+        {[]int{1, 2, 3}, 1, false, []int{1}},
+        {[]int{1, 2, 3}, 1, true, []int{3}},
+        {[]int{1, 2, 3}, 0, true, []int{}},
+        {[]int{1, 2, 3}, -1, false, []int{}},
+        {[]int{}, 2, false, []int{}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        l.EnforceMaxLength(c.n, c.dropFront)
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(append([]int{}, c.want...), 100))
+    }
+}
+
+func TestClear(t *testing.T) {
+    t.Parallel()
+    for _, seed := range [][]int{nil, {1}, {1, 2, 3}} {
+        l := fromSlice(seed)
+        l.Clear()
+        checkEmpty(t, l)
+        l.PushBack(8)
+        checkList(t, l, []int{8})
+    }
+}
+
+func TestToSliceEmpty(t *testing.T) {
+    t.Parallel()
+    got := New().ToSlice()
+    if got == nil || len(got) != 0 { t.Fatalf("ToSlice() on empty = %#v, want empty non-nil slice", got) }
+}
+
+func TestDo(t *testing.T) {
+    t.Parallel()
+    for _, seed := range [][]int{{}, {1}, {1, 2, 3, 4}} {
+        l := fromSlice(seed)
+        got := []int{}
+        l.Do(func(v int) bool { got = append(got, v); return true })
+        if !reflect.DeepEqual(got, seed) { t.Fatalf("Do visited %v, want %v", got, seed) }
+        checkList(t, l, seed)
+    }
+    l := fromSlice([]int{1, 2, 3, 4})
+    got := []int{}
+    l.Do(func(v int) bool { got = append(got, v); return v < 2 })
+    if !reflect.DeepEqual(got, []int{1, 2}) { t.Fatalf("Do did not stop when fn returned false: visited %v", got) }
+}
+
+func TestToSliceCapped(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed      []int
+        max       int
+        want      []int
+        truncated bool
+    }{
+        {[]int{}, 3, []int{}, false},
+        {[]int{1, 2, 3}, 3, []int{1, 2, 3}, false},
+        {[]int{1, 2, 3}, 5, []int{1, 2, 3}, false},
+        {[]int{1, 2, 3}, 2, []int{1, 2}, true},
+        {[]int{1, 2, 3}, 0, []int{}, true},
+        {[]int{1, 2, 3}, -1, []int{}, true},
+        {[]int{}, 0, []int{}, false},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        got, truncated := l.ToSliceCapped(c.max)
+        if !reflect.DeepEqual(got, c.want) || truncated != c.truncated {
+            t.Fatalf("ToSliceCapped(%d) on %v = %v, %t; want %v, %t", c.max, c.seed, got, truncated, c.want, c.truncated)
+        }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestPeekN(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        n    int
+        want []int
+    }{
+        {[]int{}, 2, []int{}},
+        {[]int{1, 2, 3}, 2, []int{1, 2}},
+        {[]int{1, 2, 3}, 3, []int{1, 2, 3}},
+        {[]int{1, 2, 3}, 9, []int{1, 2, 3}},
+        {[]int{1, 2, 3}, 0, []int{}},
+        {[]int{1, 2, 3}, -1, []int{}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        got := l.PeekN(c.n)
+        if !reflect.DeepEqual(got, c.want) { t.Fatalf("PeekN(%d) on %v = %v, want %v", c.n, c.seed, got, c.want) }
+        if len(got) > 0 { got[0] = -99 }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestAsStringSlice(t *testing.T) {
+    t.Parallel()
+    hex := func(v int) string { return strconv.FormatInt(int64(v), 16) }
+    cases := []struct {
+        seed []int
+        fn   func(int) string
+        want []string
+    }{
+        {[]int{}, nil, []string{}},
+        {[]int{10, -3}, nil, []string{"10", "-3"}},
+        {[]int{10, 255}, hex, []string{"a", "ff"}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if got := l.AsStringSlice(c.fn); !reflect.DeepEqual(got, c.want) { t.Fatalf("AsStringSlice on %v = %q, want %q", c.seed, got, c.want) }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestCopyIndependence(t *testing.T) {
+    t.Parallel()
+    for _, seed := range [][]int{{}, {1}, {0, 10, 20, 30}} {
+        a := fromSlice(seed)
+        b := a.Copy()
+        checkList(t, b, seed)
+        a.PushBack(40)
+        a.RemoveAt(0)
+        b.PushFront(-1)
+        checkList(t, a, append(append([]int{}, seed...), 40)[1:])
+        checkList(t, b, append([]int{-1}, seed...))
+    }
+}
+
+func TestCopyReversed(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, want []int }{
+        {[]int{}, []int{}},
+        {[]int{1}, []int{1}},
+        {[]int{1, 2, 3, 4}, []int{4, 3, 2, 1}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        r := l.CopyReversed()
+        checkList(t, r, c.want)
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestScanLeft(t *testing.T) {
+    t.Parallel()
+    product := func(acc, v int) int { return acc * v }
+    cases := []struct{ seed, want []int }{
+        {[]int{}, []int{}},
+        {[]int{5}, []int{5}},
+        {[]int{1, 2, 3, 4}, []int{1, 2, 6, 24}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        checkList(t, l.ScanLeft(1, product), c.want)
+        checkList(t, l, c.seed)
+    }
+    sums := fromSlice([]int{3, -1, 4}).ScanLeft(10, func(acc, v int) int { return acc + v })
+    checkList(t, sums, []int{13, 12, 16})
+}
+
+func TestTee(t *testing.T) {
+    t.Parallel()
+    for _, n := range []int{-1, 0} {
+        if got := fromSlice([]int{1}).Tee(n); got != nil { t.Fatalf("Tee(%d) = %v, want nil", n, got) }
+    }
+    l := fromSlice([]int{1, 2, 3})
+    copies := l.Tee(3)
+    if len(copies) != 3 { t.Fatalf("Tee(3) returned %d lists", len(copies)) }
+    copies[0].PushBack(4)
+    copies[1].Clear()
+    checkList(t, copies[0], []int{1, 2, 3, 4})
+    checkList(t, copies[1], []int{})
+    checkList(t, copies[2], []int{1, 2, 3})
+    checkList(t, l, []int{1, 2, 3})
+    if empty := New().Tee(2); len(empty) != 2 || empty[0] == empty[1] { t.Fatalf("Tee(2) of an empty list = %v", empty) }
+}
+
+func TestFrequencies(t *testing.T) {
+    t.Parallel()
+    values, counts := fromSlice([]int{3, 1, 3, 2, 1, 1}).Frequencies()
+    if !reflect.DeepEqual(values, []int{1, 2, 3}) || !reflect.DeepEqual(counts, []int{3, 1, 2}) {
+        t.Fatalf("Frequencies() = %v, %v", values, counts)
+    }
+    values, counts = New().Frequencies()
+    if len(values) != 0 || len(counts) != 0 { t.Fatalf("Frequencies() on empty = %v, %v", values, counts) }
+}
+
+func TestValuesExceeding(t *testing.T) {
+    t.Parallel()
+    seed := []int{1, 4, 2, 5, 3, 6}
+    cases := []struct {
+        threshold, count, sum int
+    }{
+        {3, 3, 15},
+        {0, 6, 21},
+        {6, 0, 0},
+        {5, 1, 6},
+        {-10, 6, 21},
+    }
+    for _, c := range cases {
+        l := fromSlice(seed)
+        if got := l.CountValuesExceeding(c.threshold); got != c.count { t.Fatalf("CountValuesExceeding(%d) = %d, want %d", c.threshold, got, c.count) }
+        if got := l.SumValuesExceeding(c.threshold); got != c.sum { t.Fatalf("SumValuesExceeding(%d) = %d, want %d", c.threshold, got, c.sum) }
+        checkList(t, l, seed)
+    }
+    l := fromSlice([]int{-3, -1, -2})
+    if c, s := l.CountValuesExceeding(-3), l.SumValuesExceeding(-3); c != 2 || s != -3 { t.Fatalf("over %v, threshold -3: count %d, sum %d, want 2, -3", l.ToSlice(), c, s) }
+    if c, s := New().CountValuesExceeding(0), New().SumValuesExceeding(0); c != 0 || s != 0 { t.Fatalf("empty list: count %d, sum %d", c, s) }
+}
+
+func TestIsSorted(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed      []int
+        asc, desc bool
+    }{
+        {[]int{}, true, true},
+        {[]int{7}, true, true},
+        {[]int{2, 2, 2}, true, true},
+        {[]int{1, 2, 2, 3}, true, false},
+        {[]int{3, 2, 2, 1}, false, true},
+        {[]int{1, 3, 2}, false, false},
+        {[]int{2, 1, 3}, false, false},
+        {[]int{-5, 0, 5}, true, false},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if got := l.IsSorted(); got != c.asc { t.Fatalf("IsSorted() on %v = %t, want %t", c.seed, got, c.asc) }
+        if got := l.IsSortedDesc(); got != c.desc { t.Fatalf("IsSortedDesc() on %v = %t, want %t", c.seed, got, c.desc) }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestMaxGap(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        gap  int
+        ok   bool
+    }{
+        {[]int{}, 0, false},
+        {[]int{5}, 0, false},
+        {[]int{5, 5}, 0, true},
+        {[]int{3, 6, 9, 1}, 3, true},
+        {[]int{10, 1}, 9, true},
+        {[]int{-4, 8, 0, 2}, 6, true},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if gap, ok := l.MaxGap(); gap != c.gap || ok != c.ok { t.Fatalf("MaxGap() on %v = %d, %t, want %d, %t", c.seed, gap, ok, c.gap, c.ok) }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestSegmentSums(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed    []int
+        lengths []int
+        want    []int
+        ok      bool
+    }{
+        {[]int{1, 1, 1, 1, 1}, []int{2, 3}, []int{2, 3}, true},
+        {[]int{1, 2, 3, 4}, []int{1, 0, 3}, []int{1, 0, 9}, true},
+        {[]int{5, -5, 7}, []int{3}, []int{7}, true},
+        {[]int{}, []int{}, []int{}, true},
+        {[]int{}, []int{0, 0}, []int{0, 0}, true},
+        {[]int{1, 1, 1}, []int{2, 2}, nil, false},
+        {[]int{1, 1, 1}, []int{2}, nil, false},
+        {[]int{1, 1, 1}, []int{4, -1}, nil, false},
+        {[]int{1, 1}, nil, nil, false},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        got, ok := l.SegmentSums(c.lengths)
+        if !reflect.DeepEqual(got, c.want) || ok != c.ok { t.Fatalf("SegmentSums(%v) on %v = (%v, %t), want (%v, %t)", c.lengths, c.seed, got, ok, c.want, c.ok) }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestWindowMax(t *testing.T) {
+    t.Parallel()
+    seed := []int{1, 3, -1, -3, 5, 3, 6, 7}
+    cases := []struct {
+        k    int
+        want []int
+    }{
+        {3, []int{3, 3, 5, 5, 6, 7}},
+        {1, seed},
+        {8, []int{7}},
+        {9, []int{}},
+        {0, []int{}},
+        {-2, []int{}},
+    }
+    for _, c := range cases {
+        l := fromSlice(seed)
+        if got := l.WindowMax(c.k); !reflect.DeepEqual(got, c.want) { t.Fatalf("WindowMax(%d) = %v, want %v", c.k, got, c.want) }
+        checkList(t, l, seed)
+    }
+    if got := fromSlice([]int{5, 4, 3, 2, 1}).WindowMax(2); !reflect.DeepEqual(got, []int{5, 4, 3, 2}) {
+        t.Fatalf("WindowMax on descending input = %v", got)
+    }
+}
+
+func TestMergeAlternating(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ a, b, want []int }{
+        {[]int{}, []int{}, []int{}},
+        {[]int{1, 3, 5}, []int{2, 4, 6}, []int{1, 2, 3, 4, 5, 6}},
+        {[]int{1, 3, 5, 7, 8}, []int{2, 4}, []int{1, 2, 3, 4, 5, 7, 8}},
+        {[]int{1}, []int{2, 4, 6, 7}, []int{1, 2, 4, 6, 7}},
+        {[]int{}, []int{2, 4}, []int{2, 4}},
+        {[]int{1, 3}, []int{}, []int{1, 3}},
+    }
+    for _, c := range cases {
+        a, b := fromSlice(c.a), fromSlice(c.b)
+        checkList(t, a.MergeAlternating(b), c.want)
+        checkList(t, a, c.a)
+        checkList(t, b, c.b)
+    }
+}
+
+func TestDeinterleave(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, even, odd []int }{
+        {[]int{}, []int{}, []int{}},
+        {[]int{1}, []int{1}, []int{}},
+        {[]int{1, 2}, []int{1}, []int{2}},
+        {[]int{1, 2, 3, 4, 5}, []int{1, 3, 5}, []int{2, 4}},
+        {[]int{7, 7, 7, 7}, []int{7, 7}, []int{7, 7}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        even, odd := l.Deinterleave()
+        checkList(t, even, c.even)
+        checkList(t, odd, c.odd)
+        checkList(t, l, c.seed)
+        checkList(t, even.MergeAlternating(odd), c.seed)
+        even.PushBack(100)
+        checkList(t, even, append(append([]int{}, c.even...), 100))
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestToPairs(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        want [][2]int
+    }{
+        {[]int{}, [][2]int{}},
+        {[]int{1}, [][2]int{}},
+        {[]int{1, 2}, [][2]int{{1, 2}}},
+        {[]int{1, 2, 3, 4}, [][2]int{{1, 2}, {2, 3}, {3, 4}}},
+        {[]int{5, 5, -1}, [][2]int{{5, 5}, {5, -1}}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        got := l.ToPairs()
+        if got == nil || !reflect.DeepEqual(got, c.want) { t.Fatalf("ToPairs() on %v = %#v, want %v", c.seed, got, c.want) }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestBucketBy(t *testing.T) {
+    t.Parallel()
+    mod3 := func(v int) int { return v % 3 }
+    cases := []struct {
+        seed []int
+        want map[int][]int
+    }{
+        {[]int{}, map[int][]int{}},
+        {[]int{1, 2, 3, 4, 5, 6}, map[int][]int{0: {3, 6}, 1: {1, 4}, 2: {2, 5}}},
+        {[]int{7, 7, 4}, map[int][]int{1: {7, 7, 4}}},
+        {[]int{-3, 3, -1}, map[int][]int{0: {-3, 3}, -1: {-1}}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        got := l.BucketBy(mod3)
+        if len(got) != len(c.want) { t.Fatalf("BucketBy on %v: %d buckets, want %d", c.seed, len(got), len(c.want)) }
+        for k, want := range c.want {
+            if got[k] == nil { t.Fatalf("BucketBy on %v: no bucket %d", c.seed, k) }
+            checkList(t, got[k], want)
+        }
+        checkList(t, l, c.seed)
+    }
+}
+
+func TestReplaceAll(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed  []int
+        count int
+        want  []int
+    }{
+        {[]int{}, 0, []int{}},
+        {[]int{1, 3}, 0, []int{1, 3}},
+        {[]int{1, 2, 3, 2}, 2, []int{1, 99, 3, 99}},
+        {[]int{2, 2}, 2, []int{99, 99}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if got := l.ReplaceAll(2, 99); got != c.count { t.Fatalf("ReplaceAll on %v = %d, want %d", c.seed, got, c.count) }
+        checkList(t, l, c.want)
+    }
+}
+
+func TestReplaceFirst(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        ok   bool
+        want []int
+    }{
+        {[]int{}, false, []int{}},
+        {[]int{1, 3}, false, []int{1, 3}},
+        {[]int{1, 2, 2, 3}, true, []int{1, 99, 2, 3}},
+        {[]int{3, 2}, true, []int{3, 99}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if ok := l.ReplaceFirst(2, 99); ok != c.ok { t.Fatalf("ReplaceFirst on %v = %t, want %t", c.seed, ok, c.ok) }
+        checkList(t, l, c.want)
+    }
+}
+
+func TestApplyAt(t *testing.T) {
+    t.Parallel()
+    double := func(v int) int { return v * 2 }
+    cases := []struct {
+        seed []int
+        idx  int
+        ok   bool
+        want []int
+    }{
+        {[]int{}, 0, false, []int{}},
+        {[]int{1, 2, 3, 4}, -1, false, []int{1, 2, 3, 4}},
+        {[]int{1, 2, 3, 4}, 0, true, []int{2, 2, 3, 4}},
+        {[]int{1, 2, 3, 4}, 2, true, []int{1, 2, 6, 4}},
+        {[]int{1, 2, 3, 4}, 3, true, []int{1, 2, 3, 8}},
+        {[]int{1, 2, 3, 4}, 4, false, []int{1, 2, 3, 4}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if ok := l.ApplyAt(c.idx, double); ok != c.ok { t.Fatalf("ApplyAt(%d) on %v = %t, want %t", c.idx, c.seed, ok, c.ok) }
+        checkList(t, l, c.want)
+    }
+}
+
+func TestClamp(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        want []int
+    }{
+        {[]int{}, []int{}},
+        {[]int{3, 7}, []int{3, 7}},
+        {[]int{-5, 0, 5, 15}, []int{0, 0, 5, 10}},
+        {[]int{11, -1}, []int{10, 0}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        l.Clamp(0, 10)
+        checkList(t, l, c.want)
+    }
+}
+
+func TestNormalizeToRange(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed   []int
+        lo, hi int
+        want   []int
+    }{
+        {[]int{10, 20, 30}, 0, 100, []int{0, 50, 100}},
+        {[]int{}, 0, 100, []int{}},
+        {[]int{7}, 0, 100, []int{7}},
+        {[]int{5, 5, 5}, 0, 100, []int{5, 5, 5}},
+        {[]int{30, 10, 20}, 0, 100, []int{100, 0, 50}},
+        {[]int{1, 2, 3, 4}, 0, 10, []int{0, 3, 7, 10}},
+        {[]int{0, 1, 2}, 0, 1, []int{0, 1, 1}},
+        {[]int{0, 1, 2}, 1, 0, []int{1, 0, 0}},
+        {[]int{0, 1, 2}, 10, 0, []int{10, 5, 0}},
+        {[]int{-4, 0, 4}, -1, 1, []int{-1, 0, 1}},
+        {[]int{3, 9}, 5, 5, []int{5, 5}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        l.NormalizeToRange(c.lo, c.hi)
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(append([]int{}, c.want...), 100))
+    }
+}
+
+func TestUniqueCounting(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed    []int
+        removed int
+        want    []int
+    }{
+        {[]int{}, 0, []int{}},
+        {[]int{4}, 0, []int{4}},
+        {[]int{1, 2, 3}, 0, []int{1, 2, 3}},
+        {[]int{1, 1, 1, 2, 2, 3}, 3, []int{1, 2, 3}},
+        {[]int{1, 2, 2, 2}, 2, []int{1, 2}},
+        {[]int{5, 5, 5}, 2, []int{5}},
+        {[]int{1, 2, 1, 1, 2}, 1, []int{1, 2, 1, 2}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if got := l.UniqueCounting(); got != c.removed { t.Fatalf("UniqueCounting() on %v = %d, want %d", c.seed, got, c.removed) }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+func TestRemoveIfAdjacentEqual(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, want []int }{
+        {[]int{}, []int{}},
+        {[]int{4}, []int{4}},
+        {[]int{1, 2, 3}, []int{1, 2, 3}},
+        {[]int{1, 2, 2, 3, 3, 4}, []int{1, 4}},
+        {[]int{1, 2, 3, 3, 2, 4}, []int{1, 4}},
+        {[]int{7, 7}, []int{}},
+        {[]int{5, 5, 5}, []int{5}},
+        {[]int{1, 2, 2, 1}, []int{}},
+        {[]int{1, 2, 3, 3}, []int{1, 2}},
+        {[]int{3, 3, 1, 2}, []int{1, 2}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        l.RemoveIfAdjacentEqual()
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+func TestRemoveWhereIndex(t *testing.T) {
+    t.Parallel()
+    every3rd := func(i int) bool { return i%3 == 2 }
+    all := func(int) bool { return true }
+    first := func(i int) bool { return i == 0 }
+    cases := []struct {
+        seed    []int
+        pred    func(int) bool
+        removed int
+        want    []int
+    }{
+        {[]int{}, all, 0, []int{}},
+        {[]int{0, 1, 2, 3, 4, 5, 6, 7, 8}, every3rd, 3, []int{0, 1, 3, 4, 6, 7}},
+        {[]int{0, 1, 2, 3, 4, 5}, every3rd, 2, []int{0, 1, 3, 4}},
+        {[]int{5, 6, 7}, all, 3, []int{}},
+        {[]int{5, 6, 7}, first, 1, []int{6, 7}},
+        {[]int{5, 6, 7}, func(i int) bool { return i > 0 }, 2, []int{5}},
+        {[]int{5, 6, 7}, func(int) bool { return false }, 0, []int{5, 6, 7}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if got := l.RemoveWhereIndex(c.pred); got != c.removed { t.Fatalf("RemoveWhereIndex on %v = %d, want %d", c.seed, got, c.removed) }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+func TestInsertSortedUnique(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        v    int
+        ok   bool
+        want []int
+    }{
+        {[]int{}, 3, true, []int{3}},
+        {[]int{3}, 3, false, []int{3}},
+        {[]int{3, 5}, 1, true, []int{1, 3, 5}},
+        {[]int{1, 5}, 3, true, []int{1, 3, 5}},
+        {[]int{1, 3}, 5, true, []int{1, 3, 5}},
+        {[]int{1, 3, 5}, 5, false, []int{1, 3, 5}},
+        {[]int{1, 3, 5}, 1, false, []int{1, 3, 5}},
+        {[]int{-2, 0}, -1, true, []int{-2, -1, 0}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if ok := l.InsertSortedUnique(c.v); ok != c.ok { t.Fatalf("InsertSortedUnique(%d) on %v = %t, want %t", c.v, c.seed, ok, c.ok) }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(append([]int{}, c.want...), 100))
+    }
+}
+
+// checkDoubly asserts d's values both ways and that every prev link mirrors
+// the next link before it.
+func checkDoubly(t *testing.T, d *DoublyLinkedList, want []int) {
+    t.Helper()
+    if got := d.ToSlice(); !reflect.DeepEqual(got, want) || d.Len() != len(want) { t.Fatalf("ToSlice = %v, Len = %d, want %v", got, d.Len(), want) }
+    rev := make([]int, 0, len(want))
+    for i := len(want) - 1; i >= 0; i-- { rev = append(rev, want[i]) }
+    if got := d.ToSliceReverse(); !reflect.DeepEqual(got, rev) { t.Fatalf("ToSliceReverse = %v, want %v", got, rev) }
+    var prev *dnode
+    for n := d.head; n != nil; prev, n = n, n.next {
+        if n.prev != prev { t.Fatalf("node %d: prev link does not point at the node before it", n.val) }
+    }
+    if d.tail != prev { t.Fatal("tail is not the last node") }
+}
+
+func TestDoublyInsertSorted(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        v    int
+        want []int
+    }{
+        {[]int{}, 3, []int{3}},
+        {[]int{3}, 1, []int{1, 3}},
+        {[]int{3}, 5, []int{3, 5}},
+        {[]int{3}, 3, []int{3, 3}},
+        {[]int{1, 3, 5, 7}, 4, []int{1, 3, 4, 5, 7}},
+        {[]int{1, 3, 5, 7, 9, 11}, 2, []int{1, 2, 3, 5, 7, 9, 11}},
+        {[]int{1, 3, 5, 7, 9, 11}, 10, []int{1, 3, 5, 7, 9, 10, 11}},
+        {[]int{1, 3, 5, 5, 7}, 5, []int{1, 3, 5, 5, 5, 7}},
+        {[]int{1, 3, 5, 7}, 0, []int{0, 1, 3, 5, 7}},
+        {[]int{1, 3, 5, 7}, 9, []int{1, 3, 5, 7, 9}},
+        {[]int{-4, -2}, -3, []int{-4, -3, -2}},
+    }
+    for _, c := range cases {
+        d := NewDoubly()
+        for _, v := range c.seed { d.PushBack(v) }
+        d.InsertSorted(c.v)
+        checkDoubly(t, d, c.want)
+        d.PushBack(100)
+        checkDoubly(t, d, append(append([]int{}, c.want...), 100))
+    }
+}
+
+// TestDoublyInsertSortedUnsorted checks that an unsorted list still gains v
+// with its links intact, wherever it goes.
+func TestDoublyInsertSortedUnsorted(t *testing.T) {
+    t.Parallel()
+    var d DoublyLinkedList
+    for _, v := range []int{5, 1, 9, 2} { d.PushBack(v) }
+    d.InsertSorted(4)
+    got := d.ToSlice()
+    if len(got) != 5 { t.Fatalf("ToSlice = %v, want 5 values", got) }
+    checkDoubly(t, &d, got)
+}
+
+func TestPushBackSorted(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        v    int
+        err  error
+        want []int
+    }{
+        {[]int{}, 3, nil, []int{3}},
+        {[]int{}, -5, nil, []int{-5}},
+        {[]int{1, 3}, 3, nil, []int{1, 3, 3}},
+        {[]int{1, 3}, 4, nil, []int{1, 3, 4}},
+        {[]int{1, 3}, 2, ErrNotSorted, []int{1, 3}},
+        {[]int{5}, -1, ErrNotSorted, []int{5}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        if err := l.PushBackSorted(c.v); err != c.err { t.Fatalf("PushBackSorted(%d) on %v = %v, want %v", c.v, c.seed, err, c.err) }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(append([]int{}, c.want...), 100))
+    }
+}
+
+func TestSwapPairs(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, want []int }{
+        {[]int{}, []int{}},
+        {[]int{1}, []int{1}},
+        {[]int{1, 2}, []int{2, 1}},
+        {[]int{1, 2, 3}, []int{2, 1, 3}},
+        {[]int{1, 2, 3, 4}, []int{2, 1, 4, 3}},
+        {[]int{1, 2, 3, 4, 5}, []int{2, 1, 4, 3, 5}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        var nodes []*node
+        for n := l.head; n != nil; n = n.next { nodes = append(nodes, n) }
+        l.SwapPairs()
+        checkList(t, l, c.want)
+        // relinked, not value-swapped: every node keeps its value
+        for i, n := range nodes {
+            if n.val != c.seed[i] { t.Fatalf("SwapPairs() on %v rewrote node values", c.seed) }
+        }
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+func TestInterquartileTrim(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, want []int }{
+        {[]int{}, []int{}},
+        {[]int{5}, []int{5}},
+        {[]int{10, 12, 11, 13, 12, 100, 11}, []int{10, 12, 11, 13, 12, 11}},
+        {[]int{-50, 1, 2, 3, 4, 50}, []int{1, 2, 3, 4}},
+        // Q1 = 1.75, Q3 = 3.25, IQR = 1.5: bounds [-0.5, 5.5] keep every value
+        {[]int{4, 1, 3, 2}, []int{4, 1, 3, 2}},
+        {[]int{7, 7, 7, 8}, []int{7, 7, 7}},
+        {[]int{100, 1, 1, 1, 1}, []int{1, 1, 1, 1}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        l.InterquartileTrim()
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+func TestRotateUntilSorted(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed      []int
+        rotations int
+        ok        bool
+        want      []int
+    }{
+        {[]int{}, 0, true, []int{}},
+        {[]int{7}, 0, true, []int{7}},
+        {[]int{1, 2, 2, 3}, 0, true, []int{1, 2, 2, 3}},
+        {[]int{3, 4, 5, 1, 2}, 3, true, []int{1, 2, 3, 4, 5}},
+        {[]int{2, 1}, 1, true, []int{1, 2}},
+        {[]int{5, 1, 2, 3, 4}, 1, true, []int{1, 2, 3, 4, 5}},
+        {[]int{2, 2, 1, 2}, 2, true, []int{1, 2, 2, 2}},
+        {[]int{3, 1, 2, 0}, 0, false, []int{3, 1, 2, 0}},
+        {[]int{3, 4, 1, 5}, 0, false, []int{3, 4, 1, 5}},
+        {[]int{2, 3, 1, 2, 3}, 0, false, []int{2, 3, 1, 2, 3}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        rotations, ok := l.RotateUntilSorted()
+        if rotations != c.rotations || ok != c.ok {
+            t.Fatalf("RotateUntilSorted() on %v = (%d, %t), want (%d, %t)", c.seed, rotations, ok, c.rotations, c.ok)
+        }
+        checkList(t, l, c.want)
+        l.PushBack(100)
+        checkList(t, l, append(c.want, 100))
+    }
+}
+
+// TestSumRecursive checks against an iterative sum; the list has no Sum
+// method, so the reference is a loop over ToSlice. The large case stays
+// well inside the default goroutine stack limit.
+func TestSumRecursive(t *testing.T) {
+    t.Parallel()
+    for _, seed := range [][]int{{}, {7}, {1, -2, 3}, BuildFromRange(0, 100000, 1).ToSlice()} {
+        l := fromSlice(seed)
+        want := 0
+        for _, v := range seed { want += v }
+        if got := l.SumRecursive(); got != want { t.Fatalf("SumRecursive() over %d values = %d, want %d", len(seed), got, want) }
+        checkList(t, l, seed)
+    }
+}
+
+func TestGapEncodeRoundTrip(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ seed, gaps []int }{
+        {[]int{}, []int{}},
+        {[]int{7}, []int{7}},
+        {[]int{1, 2, 3, 5, 8}, []int{1, 1, 1, 2, 3}},
+        {[]int{10, 4, 4, -6}, []int{10, -6, 0, -10}},
+        {[]int{-5, 5, -5}, []int{-5, 10, -10}},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        gaps := l.GapEncode()
+        if !reflect.DeepEqual(gaps, c.gaps) { t.Fatalf("GapEncode() on %v = %v, want %v", c.seed, gaps, c.gaps) }
+        checkList(t, l, c.seed)
+        checkList(t, DecodeGaps(gaps), c.seed)
+    }
+}
+
+// makeCycleForTest links the tail back to the node at index pos, corrupting
+// l into a cycle of Len()-pos nodes; pos < 0 leaves l alone.
+func makeCycleForTest(l *LinkedList, pos int) {
+    if pos < 0 || l.tail == nil { return }
+    n := l.head
+    for i := 0; i < pos; i++ { n = n.next }
+    l.tail.next = n
+}
+
+func TestCycleLength(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        seed []int
+        pos  int
+        want int
+        ok   bool
+    }{
+        {[]int{}, -1, 0, false},
+        {[]int{1}, -1, 0, false},
+        {[]int{1, 2, 3, 4, 5}, -1, 0, false},
+        {[]int{1}, 0, 1, true},
+        {[]int{1, 2}, 0, 2, true},
+        {[]int{1, 2, 3, 4, 5}, 0, 5, true},
+        {[]int{1, 2, 3, 4, 5}, 2, 3, true},
+        {[]int{1, 2, 3, 4, 5}, 4, 1, true},
+        {[]int{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}, 3, 7, true},
+    }
+    for _, c := range cases {
+        l := fromSlice(c.seed)
+        makeCycleForTest(l, c.pos)
+        if n, ok := l.CycleLength(); n != c.want || ok != c.ok {
+            t.Fatalf("CycleLength() on %v with tail linked to %d = (%d, %t), want (%d, %t)", c.seed, c.pos, n, ok, c.want, c.ok)
+        }
+    }
+}
+
+// TestEqualChecksumCollision stubs checksumOf so that every list of a given
+// size has the same checksum: Equal must then fall through to the walk and
+// still tell unequal lists apart. It swaps a package variable, so it must
+// not run in parallel.
+func TestEqualChecksumCollision(t *testing.T) {
+    saved := checksumOf
+    defer func() { checksumOf = saved }()
+    checksumOf = func(int) uint64 { return 1 }
+    cases := []struct {
+        a, b []int
+        want bool
+    }{
+        {[]int{1, 2, 3}, []int{1, 2, 3}, true},
+        {[]int{1, 2, 3}, []int{1, 2, 4}, false},
+        {[]int{1, 2, 3}, []int{7, 8, 9}, false},
+        {[]int{}, []int{}, true},
+    }
+    for _, c := range cases {
+        a, b := fromSlice(c.a), fromSlice(c.b)
+        if a.Checksum() != b.Checksum() { t.Fatalf("stubbed checksums of %v and %v differ", c.a, c.b) }
+        if got := a.Equal(b); got != c.want { t.Fatalf("Equal(%v, %v) with colliding checksums = %t, want %t", c.a, c.b, got, c.want) }
+    }
+}
+
+func TestChecksumMoves(t *testing.T) {
+    t.Parallel()
+    src := fromSlice([]int{4, 5, 6})
+    dst := MoveFrom(src)
+    checkList(t, dst, []int{4, 5, 6})
+    checkEmpty(t, src)
+    other := fromSlice([]int{1})
+    other.MoveAssignFrom(dst)
+    checkList(t, other, []int{4, 5, 6})
+    checkEmpty(t, dst)
+    if fromSlice([]int{1, 2}).Checksum() == fromSlice([]int{1, 3}).Checksum() { t.Fatal("[1 2] and [1 3] have the same checksum") }
+}
+
+func TestEqual(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        a, b []int
+        want bool
+    }{
+        {[]int{}, []int{}, true},
+        {[]int{1, 2, 3}, []int{1, 2, 3}, true},
+        {[]int{1, 2, 3}, []int{1, 2}, false},
+        {[]int{}, []int{0}, false},
+        {[]int{1, 2, 3}, []int{1, 2, 4}, false},
+        {[]int{9, 2, 3}, []int{1, 2, 3}, false},
+        // same size, sum and multiset: any order-insensitive checksum collides
+        {[]int{1, 2, 3}, []int{3, 2, 1}, false},
+    }
+    for _, c := range cases {
+        a, b := fromSlice(c.a), fromSlice(c.b)
+        if got := a.Equal(b); got != c.want { t.Fatalf("Equal(%v, %v) = %t, want %t", c.a, c.b, got, c.want) }
+        if got := b.Equal(a); got != c.want { t.Fatalf("Equal(%v, %v) = %t, want %t", c.b, c.a, got, c.want) }
+        checkList(t, a, c.a)
+        checkList(t, b, c.b)
+    }
+}
+
+func TestEqualAsSet(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        a, b         []int
+        eq           bool
+        onlyA, onlyB []int
+    }{
+        {[]int{}, []int{}, true, nil, nil},
+        {[]int{3, 1, 2}, []int{1, 2, 3}, true, nil, nil},
+        {[]int{1, 1, 2}, []int{2, 1, 2, 2}, true, nil, nil},
+        {[]int{5, 1}, []int{4, 2, 3}, false, []int{1, 5}, []int{2, 3, 4}},
+        {[]int{1, 2, 9, 2}, []int{2, 3, 1}, false, []int{9}, []int{3}},
+        {[]int{}, []int{-1, 0, -1}, false, nil, []int{-1, 0}},
+    }
+    for _, c := range cases {
+        a, b := fromSlice(c.a), fromSlice(c.b)
+        eq, onlyA, onlyB := a.EqualAsSet(b)
+        if eq != c.eq || !reflect.DeepEqual(onlyA, c.onlyA) || !reflect.DeepEqual(onlyB, c.onlyB) {
+            t.Fatalf("EqualAsSet(%v, %v) = (%t, %v, %v), want (%t, %v, %v)", c.a, c.b, eq, onlyA, onlyB, c.eq, c.onlyA, c.onlyB)
+        }
+        if eq, onlyB, onlyA := b.EqualAsSet(a); eq != c.eq || !reflect.DeepEqual(onlyA, c.onlyA) || !reflect.DeepEqual(onlyB, c.onlyB) {
+            t.Fatalf("EqualAsSet(%v, %v) is not the mirror image", c.b, c.a)
+        }
+        checkList(t, a, c.a)
+        checkList(t, b, c.b)
+    }
+}
+
+func TestDiffAgainst(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        got, want []int
+        diffs     []Diff
+    }{
+        {[]int{}, []int{}, nil},
+        {[]int{1, 2, 3}, []int{1, 2, 3}, nil},
+        {[]int{1, 9, 3, 7}, []int{1, 2, 3, 4}, []Diff{{1, 9, 2, "mismatch"}, {3, 7, 4, "mismatch"}}},
+        {[]int{1, 2}, []int{1, 2, 3, 4}, []Diff{{2, 0, 3, "missing"}, {3, 0, 4, "missing"}}},
+        {[]int{1, 2, 3}, []int{1}, []Diff{{1, 2, 0, "extra"}, {2, 3, 0, "extra"}}},
+        {[]int{5, 2, 6}, []int{1, 2}, []Diff{{0, 5, 1, "mismatch"}, {2, 6, 0, "extra"}}},
+        {[]int{}, []int{7}, []Diff{{0, 0, 7, "missing"}}},
+    }
+    for _, c := range cases {
+        l, w := fromSlice(c.got), fromSlice(c.want)
+        if got := l.DiffAgainst(w); !reflect.DeepEqual(got, c.diffs) {
+            t.Fatalf("DiffAgainst(%v, %v) = %v, want %v", c.got, c.want, got, c.diffs)
+        }
+        checkList(t, l, c.got)
+        checkList(t, w, c.want)
+    }
+}
+
+func TestDiffJSON(t *testing.T) {
+    t.Parallel()
+    cases := []struct {
+        got, want []int
+        json      string
+    }{
+        {[]int{1, 2}, []int{1, 2}, `{"equal":true,"diffs":[]}`},
+        {[]int{}, []int{}, `{"equal":true,"diffs":[]}`},
+        {[]int{1, 9, 3}, []int{1, 2}, `{"equal":false,"diffs":[{"index":1,"got":9,"want":2,"kind":"mismatch"},{"index":2,"got":3,"want":0,"kind":"extra"}]}`},
+    }
+    for _, c := range cases {
+        data, err := fromSlice(c.got).DiffJSON(fromSlice(c.want))
+        if err != nil { t.Fatal(err) }
+        if string(data) != c.json { t.Fatalf("DiffJSON(%v, %v) = %s, want %s", c.got, c.want, data, c.json) }
+    }
+}
+
+func TestMoveFrom(t *testing.T) {
+    t.Parallel()
+    for _, seed := range [][]int{{}, {1}, {1, 2, 3}} {
+        src := fromSlice(seed)
+        dst := MoveFrom(src)
+        checkList(t, dst, seed)
+        checkEmpty(t, src)
+        src.PushBack(7)
+        checkList(t, src, []int{7})
+        checkList(t, dst, seed)
+    }
+}
+
+func TestMoveAssignFrom(t *testing.T) {
+    t.Parallel()
+    cases := []struct{ dst, src []int }{
+        {[]int{}, []int{}},
+        {[]int{}, []int{1, 2}},
+        {[]int{9, 8, 7}, []int{}},
+        {[]int{9, 8, 7}, []int{1, 2}},
+    }
+    for _, c := range cases {
+        dst, src := fromSlice(c.dst), fromSlice(c.src)
+        dst.MoveAssignFrom(src)
+        checkList(t, dst, c.src)
+        checkEmpty(t, src)
+        dst.PushBack(5)
+        checkEmpty(t, src)
+    }
+}
+
+// TestListAPIMatchesDriver checks that list_api.go, which lets the memo
+// build on its own, is still the driver's main/list_api.go byte for byte.
+// The memo ships without main/, so the check is skipped there.
+func TestListAPIMatchesDriver(t *testing.T) {
+    want, err := os.ReadFile(filepath.Join("..", "main", "list_api.go"))
+    if os.IsNotExist(err) { t.Skip("no ../main/list_api.go next to the memo") }
+    if err != nil { t.Fatal(err) }
+    got, err := os.ReadFile("list_api.go")
+    if err != nil { t.Fatal(err) }
+    if !bytes.Equal(got, want) { t.Fatal("list_api.go is stale; copy main/list_api.go over it") }
+}
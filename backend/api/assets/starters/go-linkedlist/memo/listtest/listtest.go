@@ -0,0 +1,107 @@
+// Package listtest holds the assertions the memo's tests make about a list's
+// state. A failure prints the list in full with its length and both ends, so
+// it says what the list holds, not only which check tripped.
+//
+// The helpers read lists through the List and Chain interfaces rather than
+// *LinkedList, so they serve any list of comparable values, a generic
+// variant of the memo included.
+package listtest
+
+import "fmt"
+
+// TB is the part of testing.TB the helpers use.
+type TB interface {
+    Helper()
+    Fatalf(format string, args ...interface{})
+}
+
+// List is the read-only API the helpers check.
+type List[T comparable] interface {
+    ToSlice() []T
+    Len() int
+    IsEmpty() bool
+    Front() (T, bool)
+    Back() (T, bool)
+}
+
+// Chain is a List that also reports what only its nodes show: the values
+// reachable from head, the size it stores and whether tail is the last
+// reachable node. Tests inside the list's package provide it with a small
+// adapter.
+type Chain[T comparable] interface {
+    List[T]
+    Chain() (reachable []T, size int, tailIsLast bool)
+}
+
+// Describe formats l as its values, length and ends, such as
+// "[1 2 3] (len 3, front 1, back 3)", or "[] (len 0, empty)" for an empty
+// list whose Front and Back report nothing.
+func Describe[T comparable](l List[T]) string {
+    f, okF := l.Front()
+    b, okB := l.Back()
+    if l.Len() == 0 && !okF && !okB { return fmt.Sprintf("%v (len 0, empty)", l.ToSlice()) }
+    return fmt.Sprintf("%v (len %d, front %s, back %s)", l.ToSlice(), l.Len(), end(f, okF), end(b, okB))
+}
+
+// end formats a Front or Back result.
+func end[T any](v T, ok bool) string {
+    if !ok { return "none" }
+    return fmt.Sprint(v)
+}
+
+// AssertList fails t unless l holds want from front to back and its Len,
+// IsEmpty, Front and Back agree with that.
+func AssertList[T comparable](t TB, l List[T], want []T) {
+    t.Helper()
+    if reason := mismatch(l, want); reason != "" { t.Fatalf("%s: list is %s, want %v", reason, Describe(l), want) }
+}
+
+// AssertEmpty fails t unless l is empty by every observer: no values, Len 0,
+// IsEmpty true, and Front and Back reporting nothing.
+func AssertEmpty[T comparable](t TB, l List[T]) {
+    t.Helper()
+    if reason := mismatch(l, nil); reason != "" { t.Fatalf("%s: list is %s, want it empty", reason, Describe(l)) }
+}
+
+// mismatch names the first observer of l that disagrees with want, or
+// returns "".
+func mismatch[T comparable](l List[T], want []T) string {
+    got := l.ToSlice()
+    if !equal(got, want) { return "values differ" }
+    if n := l.Len(); n != len(want) { return fmt.Sprintf("Len() = %d", n) }
+    if e := l.IsEmpty(); e != (len(want) == 0) { return fmt.Sprintf("IsEmpty() = %t", e) }
+    var zero T
+    f, okF := l.Front()
+    b, okB := l.Back()
+    if len(want) == 0 {
+        if okF || f != zero { return fmt.Sprintf("Front() = (%v, %t)", f, okF) }
+        if okB || b != zero { return fmt.Sprintf("Back() = (%v, %t)", b, okB) }
+        return ""
+    }
+    if !okF || f != want[0] { return fmt.Sprintf("Front() = (%v, %t)", f, okF) }
+    if !okB || b != want[len(want)-1] { return fmt.Sprintf("Back() = (%v, %t)", b, okB) }
+    return ""
+}
+
+// AssertInvariants fails t unless l's stored size counts the nodes reachable
+// from head, tail is the last of them and Len reports the stored size.
+func AssertInvariants[T comparable](t TB, l Chain[T]) {
+    t.Helper()
+    reachable, size, tailIsLast := l.Chain()
+    switch {
+    case size != len(reachable):
+        t.Fatalf("size is %d but %d nodes are reachable from head %v: list is %s", size, len(reachable), reachable, Describe(l))
+    case !tailIsLast:
+        t.Fatalf("tail is not the last node reachable from head %v: list is %s", reachable, Describe(l))
+    case l.Len() != size:
+        t.Fatalf("Len() = %d but size is %d: list is %s", l.Len(), size, Describe(l))
+    }
+}
+
+func equal[T comparable](a, b []T) bool {
+    if len(a) != len(b) { return false }
+    for i := range a {
+        if a[i] != b[i] { return false }
+    }
+    return true
+}
@@ -0,0 +1,118 @@
+package listtest
+
+import (
+    "fmt"
+    "testing"
+)
+
+// recorder is a TB that keeps the first failure instead of stopping.
+type recorder struct{ msg string }
+
+func (r *recorder) Helper() {}
+
+func (r *recorder) Fatalf(format string, args ...interface{}) {
+    if r.msg == "" { r.msg = fmt.Sprintf(format, args...) }
+}
+
+// fake is a slice-backed list whose observers can be made to lie, so each
+// check can be tripped on its own.
+type fake[T comparable] struct {
+    vs         []T
+    lenDelta   int
+    emptyLie   bool
+    frontLie   bool
+    size       int
+    tailIsLast bool
+}
+
+func newFake[T comparable](vs ...T) *fake[T] { return &fake[T]{vs: vs, size: len(vs), tailIsLast: true} }
+
+func (f *fake[T]) ToSlice() []T  { return append([]T{}, f.vs...) }
+func (f *fake[T]) Len() int      { return len(f.vs) + f.lenDelta }
+func (f *fake[T]) IsEmpty() bool { return (len(f.vs) == 0) != f.emptyLie }
+
+func (f *fake[T]) Front() (T, bool) {
+    var zero T
+    if f.frontLie { return zero, len(f.vs) == 0 }
+    if len(f.vs) == 0 { return zero, false }
+    return f.vs[0], true
+}
+
+func (f *fake[T]) Back() (T, bool) {
+    var zero T
+    if len(f.vs) == 0 { return zero, false }
+    return f.vs[len(f.vs)-1], true
+}
+
+func (f *fake[T]) Chain() ([]T, int, bool) { return f.ToSlice(), f.size, f.tailIsLast }
+
+func TestDescribe(t *testing.T) {
+    cases := []struct {
+        l    List[int]
+        want string
+    }{
+        {newFake[int](), "[] (len 0, empty)"},
+        {newFake(7), "[7] (len 1, front 7, back 7)"},
+        {newFake(1, 2, 3), "[1 2 3] (len 3, front 1, back 3)"},
+        {&fake[int]{frontLie: true}, "[] (len 0, front 0, back none)"},
+        {&fake[int]{vs: []int{4, 5}, frontLie: true}, "[4 5] (len 2, front none, back 5)"},
+    }
+    for _, c := range cases {
+        if got := Describe(c.l); got != c.want { t.Errorf("Describe(%v) = %q, want %q", c.l.ToSlice(), got, c.want) }
+    }
+    if got := Describe[string](newFake("a", "b")); got != "[a b] (len 2, front a, back b)" { t.Errorf("Describe on strings = %q", got) }
+}
+
+func TestAssertMessages(t *testing.T) {
+    cases := []struct {
+        name   string
+        assert func(TB)
+        want   string
+    }{
+        {"match", func(tb TB) { AssertList(tb, newFake(1, 2), []int{1, 2}) }, ""},
+        {"values", func(tb TB) { AssertList(tb, newFake(1, 2), []int{1, 3}) },
+            "values differ: list is [1 2] (len 2, front 1, back 2), want [1 3]"},
+        {"len", func(tb TB) { AssertList(tb, &fake[int]{vs: []int{1, 2}, lenDelta: 1}, []int{1, 2}) },
+            "Len() = 3: list is [1 2] (len 3, front 1, back 2), want [1 2]"},
+        {"is-empty", func(tb TB) { AssertList(tb, &fake[int]{vs: []int{9}, emptyLie: true}, []int{9}) },
+            "IsEmpty() = true: list is [9] (len 1, front 9, back 9), want [9]"},
+        {"front", func(tb TB) { AssertList(tb, &fake[int]{vs: []int{4, 5}, frontLie: true}, []int{4, 5}) },
+            "Front() = (0, false): list is [4 5] (len 2, front none, back 5), want [4 5]"},
+        {"empty", func(tb TB) { AssertEmpty[int](tb, newFake[int]()) }, ""},
+        {"not-empty", func(tb TB) { AssertEmpty[int](tb, newFake(3)) },
+            "values differ: list is [3] (len 1, front 3, back 3), want it empty"},
+        {"empty-front", func(tb TB) { AssertEmpty[int](tb, &fake[int]{frontLie: true}) },
+            "Front() = (0, true): list is [] (len 0, front 0, back none), want it empty"},
+        {"strings", func(tb TB) { AssertList(tb, newFake("x"), []string{"y"}) },
+            "values differ: list is [x] (len 1, front x, back x), want [y]"},
+    }
+    for _, c := range cases {
+        var r recorder
+        c.assert(&r)
+        if r.msg != c.want { t.Errorf("%s: message %q, want %q", c.name, r.msg, c.want) }
+    }
+}
+
+func TestAssertInvariantsMessages(t *testing.T) {
+    size := newFake(1, 2, 3)
+    size.size = 2
+    tail := newFake(1, 2)
+    tail.tailIsLast = false
+    length := newFake(1, 2)
+    length.lenDelta = -1
+    cases := []struct {
+        l    Chain[int]
+        want string
+    }{
+        {newFake(1, 2), ""},
+        {newFake[int](), ""},
+        {size, "size is 2 but 3 nodes are reachable from head [1 2 3]: list is [1 2 3] (len 3, front 1, back 3)"},
+        {tail, "tail is not the last node reachable from head [1 2]: list is [1 2] (len 2, front 1, back 2)"},
+        {length, "Len() = 1 but size is 2: list is [1 2] (len 1, front 1, back 2)"},
+    }
+    for _, c := range cases {
+        var r recorder
+        AssertInvariants(&r, c.l)
+        if r.msg != c.want { t.Errorf("AssertInvariants on %v: message %q, want %q", c.l.ToSlice(), r.msg, c.want) }
+    }
+}
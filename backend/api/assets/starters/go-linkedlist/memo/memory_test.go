@@ -0,0 +1,45 @@
+package main
+
+import (
+    "runtime"
+    "testing"
+)
+
+const releaseNodes = 1000000
+
+func heapObjects() int64 {
+    runtime.GC()
+    var ms runtime.MemStats
+    runtime.ReadMemStats(&ms)
+    return int64(ms.HeapObjects)
+}
+
+// checkReleased fills a list, empties it with drain and checks the nodes
+// became garbage: an implementation that still references the old chain
+// (a debug slice of popped nodes, a stale head) keeps ~releaseNodes objects
+// live. The bands are loose because the runtime allocates on its own too.
+func checkReleased(t *testing.T, drain func(*LinkedList)) {
+    t.Helper()
+    if testing.Short() { t.Skip("slow: allocates a million nodes") }
+    l := New()
+    base := heapObjects()
+    for i := 0; i < releaseNodes; i++ { l.PushBack(i) }
+    if full := heapObjects() - base; full < releaseNodes*9/10 {
+        t.Fatalf("only %d heap objects after %d pushes; measurement is off", full, releaseNodes)
+    }
+    drain(l)
+    if left := heapObjects() - base; left > releaseNodes/10 {
+        t.Fatalf("%d heap objects still live after emptying a %d-node list", left, releaseNodes)
+    }
+    runtime.KeepAlive(l)
+}
+
+func TestClearReleasesNodes(t *testing.T) {
+    checkReleased(t, func(l *LinkedList) { l.Clear() })
+}
+
+func TestPopFrontReleasesNodes(t *testing.T) {
+    checkReleased(t, func(l *LinkedList) {
+        for !l.IsEmpty() { l.PopFront() }
+    })
+}
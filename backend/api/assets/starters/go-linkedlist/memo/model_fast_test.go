@@ -0,0 +1,7 @@
+//go:build !slowtests
+
+package main
+
+// modelSequences is the number of random sequences TestModelRandomSequences
+// runs; build with -tags slowtests for a longer soak.
+const modelSequences = 1000
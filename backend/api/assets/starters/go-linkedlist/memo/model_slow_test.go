@@ -0,0 +1,5 @@
+//go:build slowtests
+
+package main
+
+const modelSequences = 50000
@@ -0,0 +1,154 @@
+package main
+
+import (
+    "fmt"
+    "reflect"
+    "strings"
+    "testing"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/altimpl"
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/internal/opgen"
+)
+
+// Model-based tests: random operation sequences are applied to the memo list
+// and to a plain slice, and the two must agree after every step. The naive
+// implementations in altimpl run through the same sequences, so a wrong
+// expectation in the model shows up as all three disagreeing with it.
+
+type opKind int
+
+// The kinds mirror opgen's so generated sequences convert directly.
+const (
+    opPushFront = opKind(opgen.PushFront)
+    opPushBack  = opKind(opgen.PushBack)
+    opPopFront  = opKind(opgen.PopFront)
+    opInsertAt  = opKind(opgen.InsertAt)
+    opRemoveAt  = opKind(opgen.RemoveAt)
+    opClear     = opKind(opgen.Clear)
+    numOpKinds  = opKind(opgen.NumKinds)
+)
+
+var opNames = [...]string{"opPushFront", "opPushBack", "opPopFront", "opInsertAt", "opRemoveAt", "opClear"}
+
+type op struct {
+    kind     opKind
+    idx, val int
+}
+
+func (o op) String() string { return fmt.Sprintf("{%s, %d, %d}", opNames[o.kind], o.idx, o.val) }
+
+const (
+    modelSeed   = 20250301
+    modelOpsLen = 200
+)
+
+// regressions holds op sequences that once diverged from the model; paste the
+// sequence printed by a failing TestModelRandomSequences here to pin it.
+var regressions = [][]op{
+    {{opPushBack, 0, 7}, {opRemoveAt, 0, 0}, {opPushBack, 0, 9}},
+    {{opPushBack, 0, 1}, {opPushBack, 0, 2}, {opRemoveAt, 1, 0}, {opInsertAt, 1, 3}, {opPushBack, 0, 4}},
+    {{opPushFront, 0, 1}, {opClear, 0, 0}, {opInsertAt, 0, 2}, {opPopFront, 0, 0}, {opPushBack, 0, 3}},
+}
+
+// impls are the list implementations the model tests and BenchmarkImpls run.
+var impls = []struct {
+    name string
+    new  func() altimpl.List
+}{
+    {"memo", func() altimpl.List { return New() }},
+    {"slice", altimpl.NewSliceList},
+    {"no-tail", altimpl.NewNoTailList},
+    {"array", func() altimpl.List { return NewArrayList() }},
+}
+
+// randomOps takes the next n ops from g.
+func randomOps(g *opgen.Gen, n int) []op {
+    ops := make([]op, n)
+    for i := range ops {
+        o := g.Next()
+        ops[i] = op{kind: opKind(o.Kind), idx: o.Idx, val: o.Val}
+    }
+    return ops
+}
+
+// applyModel applies o to both the list and the slice model, returning the
+// updated model and whether the list's return values agreed with the model's.
+func applyModel(l altimpl.List, model []int, o op) ([]int, bool) {
+    switch o.kind {
+    case opPushFront:
+        l.PushFront(o.val)
+        return append([]int{o.val}, model...), true
+    case opPushBack:
+        l.PushBack(o.val)
+        return append(model, o.val), true
+    case opPopFront:
+        ok, v := l.PopFront()
+        if len(model) == 0 { return model, !ok && v == 0 }
+        return model[1:], ok && v == model[0]
+    case opInsertAt:
+        ok := l.InsertAt(o.idx, o.val)
+        if o.idx < 0 || o.idx > len(model) { return model, !ok }
+        next := append(append(append([]int{}, model[:o.idx]...), o.val), model[o.idx:]...)
+        return next, ok
+    case opRemoveAt:
+        ok := l.RemoveAt(o.idx)
+        if o.idx < 0 || o.idx >= len(model) { return model, !ok }
+        return append(append([]int{}, model[:o.idx]...), model[o.idx+1:]...), ok
+    case opClear:
+        l.Clear()
+        return []int{}, true
+    }
+    panic("unknown op")
+}
+
+func agrees(l altimpl.List, model []int) bool {
+    if !reflect.DeepEqual(l.ToSlice(), model) || l.Len() != len(model) { return false }
+    f, okF := l.Front()
+    b, okB := l.Back()
+    if len(model) == 0 { return !okF && !okB }
+    return okF && okB && f == model[0] && b == model[len(model)-1]
+}
+
+// runModel replays ops on a list from newList and reports the first step at
+// which the list diverged, or -1.
+func runModel(newList func() altimpl.List, ops []op) (int, []int, altimpl.List) {
+    l, model := newList(), []int{}
+    for i, o := range ops {
+        var ok bool
+        model, ok = applyModel(l, model, o)
+        if !ok || !agrees(l, model) { return i, model, l }
+    }
+    return -1, model, l
+}
+
+func formatOps(ops []op) string {
+    parts := make([]string, len(ops))
+    for i, o := range ops { parts[i] = o.String() }
+    return "{" + strings.Join(parts, ", ") + "}"
+}
+
+func TestModelRandomSequences(t *testing.T) {
+    for _, impl := range impls {
+        t.Run(impl.name, func(t *testing.T) {
+            for seq := 0; seq < modelSequences; seq++ {
+                ops := randomOps(opgen.New(modelSeed+int64(seq)), modelOpsLen)
+                if step, model, l := runModel(impl.new, ops); step >= 0 {
+                    t.Fatalf("sequence %d diverged at op %d (%s): list %v (len %d), model %v\nreplay with:\n%s",
+                        seq, step, ops[step], l.ToSlice(), l.Len(), model, formatOps(ops[:step+1]))
+                }
+            }
+        })
+    }
+}
+
+func TestModelRegressions(t *testing.T) {
+    for _, impl := range impls {
+        t.Run(impl.name, func(t *testing.T) {
+            for i, ops := range regressions {
+                if step, model, l := runModel(impl.new, ops); step >= 0 {
+                    t.Errorf("regression %d diverged at op %d (%s): list %v, model %v", i, step, ops[step], l.ToSlice(), model)
+                }
+            }
+        })
+    }
+}
@@ -0,0 +1,114 @@
+package main
+
+import "testing"
+
+// nodes is a test-only accessor returning l's nodes in order, so the move
+// tests can compare node identity rather than just values.
+func (l *LinkedList) nodes() []*node {
+    var ns []*node
+    for n := l.head; n != nil; n = n.next { ns = append(ns, n) }
+    return ns
+}
+
+// sameNodes fails unless got holds exactly the nodes in want, in order.
+func sameNodes(t *testing.T, what string, got, want []*node) {
+    t.Helper()
+    if len(got) != len(want) { t.Fatalf("%s: %d nodes, want %d", what, len(got), len(want)) }
+    for i := range want {
+        if got[i] != want[i] { t.Fatalf("%s: node %d is a different node (copied instead of moved?)", what, i) }
+    }
+}
+
+func checkZeroed(t *testing.T, what string, l *LinkedList) {
+    t.Helper()
+    if l.head != nil || l.tail != nil || l.size != 0 { t.Fatalf("%s: source not zeroed: head=%p tail=%p size=%d", what, l.head, l.tail, l.size) }
+}
+
+func TestMoveFromTransfersNodes(t *testing.T) {
+    for _, seed := range [][]int{{}, {1}, {1, 2, 3}} {
+        src := fromSlice(seed)
+        before, tail := src.nodes(), src.tail
+        dst := MoveFrom(src)
+        sameNodes(t, "MoveFrom", dst.nodes(), before)
+        if dst.tail != tail { t.Fatalf("MoveFrom(%v): destination tail is not the source's tail node", seed) }
+        checkZeroed(t, "MoveFrom", src)
+    }
+}
+
+func TestMoveAssignFromTransfersNodes(t *testing.T) {
+    cases := []struct{ dst, src []int }{
+        {[]int{}, []int{}},
+        {[]int{}, []int{1, 2}},
+        {[]int{9, 8, 7}, []int{}},
+        {[]int{9, 8, 7}, []int{1, 2}},
+    }
+    for _, c := range cases {
+        dst, src := fromSlice(c.dst), fromSlice(c.src)
+        old, before, tail := dst.nodes(), src.nodes(), src.tail
+        dst.MoveAssignFrom(src)
+        sameNodes(t, "MoveAssignFrom", dst.nodes(), before)
+        if dst.tail != tail { t.Fatalf("MoveAssignFrom(%v into %v): destination tail is not the source's tail node", c.src, c.dst) }
+        checkZeroed(t, "MoveAssignFrom", src)
+        // the destination's previous nodes must be gone from both lists
+        for _, n := range append(dst.nodes(), src.nodes()...) {
+            for _, o := range old {
+                if n == o { t.Fatalf("MoveAssignFrom(%v into %v): old destination node %d still reachable", c.src, c.dst, o.val) }
+            }
+        }
+    }
+}
+
+// disjointNodes fails if any node of a is also a node of b.
+func disjointNodes(t *testing.T, what string, a, b []*node) {
+    t.Helper()
+    shared := make(map[*node]bool, len(a))
+    for _, n := range a { shared[n] = true }
+    for i, n := range b {
+        if shared[n] { t.Fatalf("%s: node %d (value %d) is shared with the source (shallow copy?)", what, i, n.val) }
+    }
+}
+
+// TestCopiesShareNoNodes checks that every copying method builds a fresh node
+// chain: the copy's nodes are disjoint from the source's, and writing through
+// either list leaves the other intact.
+func TestCopiesShareNoNodes(t *testing.T) {
+    copiers := []struct {
+        name string
+        copy func(l *LinkedList) *LinkedList
+        want func(seed []int) []int
+    }{
+        {"Copy", (*LinkedList).Copy, func(seed []int) []int { return seed }},
+        {"CopyReversed", (*LinkedList).CopyReversed, func(seed []int) []int {
+            rev := make([]int, len(seed))
+            for i, v := range seed { rev[len(seed)-1-i] = v }
+            return rev
+        }},
+        {"Tee", func(l *LinkedList) *LinkedList { return l.Tee(1)[0] }, func(seed []int) []int { return seed }},
+    }
+    for _, c := range copiers {
+        for _, seed := range [][]int{{}, {1}, {1, 2, 3}} {
+            src := fromSlice(seed)
+            before := src.nodes()
+            dst := c.copy(src)
+            sameNodes(t, c.name+" source", src.nodes(), before)
+            disjointNodes(t, c.name, before, dst.nodes())
+            checkList(t, dst, c.want(seed))
+            dst.ApplyAt(0, func(v int) int { return v + 100 })
+            dst.PushBack(7)
+            checkList(t, src, seed)
+            src.PushFront(8)
+            checkList(t, dst, append(bumpFirst(c.want(seed)), 7))
+        }
+    }
+    copies := fromSlice([]int{1, 2}).Tee(3)
+    for i := range copies {
+        for j := i + 1; j < len(copies); j++ { disjointNodes(t, "Tee sibling", copies[i].nodes(), copies[j].nodes()) }
+    }
+}
+
+// bumpFirst returns vs with 100 added to its first value, as ApplyAt(0, +100) does.
+func bumpFirst(vs []int) []int {
+    out := append([]int(nil), vs...)
+    if len(out) > 0 { out[0] += 100 }
+    return out
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+    "sync"
+    "sync/atomic"
+    "testing"
+)
+
+// These tests are meant to run under the race detector (./test.sh runs them
+// with -race); without it they still check conservation and exclusivity.
+
+const (
+    safeWorkers = 8
+    safeOpsEach = 2000
+)
+
+// TestSafeListConservation runs producers and consumers against one list
+// while a watcher samples Len. Every pushed value must end up either popped
+// or still in the list, and Len must never be seen below zero.
+func TestSafeListConservation(t *testing.T) {
+    t.Parallel()
+    s := NewSafeList()
+    var pushed, popped, minLen atomic.Int64
+    var producers, consumers sync.WaitGroup
+    done := make(chan struct{})
+
+    watched := make(chan struct{})
+    go func() {
+        defer close(watched)
+        for {
+            if n := int64(s.Len()); n < minLen.Load() { minLen.Store(n) }
+            select {
+            case <-done: return
+            default:
+            }
+        }
+    }()
+
+    for w := 0; w < safeWorkers; w++ {
+        producers.Add(1)
+        go func(w int) {
+            defer producers.Done()
+            for i := 1; i <= safeOpsEach; i++ {
+                v := w*safeOpsEach + i
+                if i%2 == 0 { s.PushFront(v) } else { s.PushBack(v) }
+                pushed.Add(int64(v))
+            }
+        }(w)
+        consumers.Add(1)
+        go func() {
+            defer consumers.Done()
+            for i := 0; i < safeOpsEach; i++ {
+                if ok, v := s.PopFront(); ok { popped.Add(int64(v)) }
+            }
+        }()
+    }
+    producers.Wait()
+    consumers.Wait()
+    close(done)
+    <-watched
+
+    var rest int64
+    for _, v := range s.ToSlice() { rest += int64(v) }
+    if got, want := popped.Load()+rest, pushed.Load(); got != want {
+        t.Fatalf("popped %d + remaining %d = %d, want pushed sum %d", popped.Load(), rest, got, want)
+    }
+    if m := minLen.Load(); m < 0 { t.Fatalf("Len observed at %d", m) }
+}
+
+// TestSafeListPopFrontExclusive is the regression for the check-then-act
+// bug: with Front() followed by PopFront() two goroutines can both see the
+// same front value and one of them then removes a value it never looked at.
+// SafeList.PopFront reads and removes under one lock, so draining the list
+// from many goroutines must hand out every value exactly once.
+func TestSafeListPopFrontExclusive(t *testing.T) {
+    t.Parallel()
+    const n = safeWorkers * safeOpsEach
+    s := NewSafeList()
+    for i := 0; i < n; i++ { s.PushBack(i) }
+
+    seen := make([]atomic.Int32, n)
+    var wg sync.WaitGroup
+    for w := 0; w < safeWorkers; w++ {
+        wg.Add(1)
+        go func() {
+            defer wg.Done()
+            for {
+                ok, v := s.PopFront()
+                if !ok { return }
+                seen[v].Add(1)
+            }
+        }()
+    }
+    wg.Wait()
+
+    for v := range seen {
+        if c := seen[v].Load(); c != 1 { t.Fatalf("value %d popped %d times, want 1", v, c) }
+    }
+    if s.Len() != 0 { t.Fatalf("Len = %d after draining, want 0", s.Len()) }
+}
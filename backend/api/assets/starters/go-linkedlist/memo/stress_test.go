@@ -0,0 +1,70 @@
+package main
+
+import (
+    "reflect"
+    "testing"
+    "time"
+
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/altimpl"
+    "github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/memo/internal/opgen"
+)
+
+const (
+    stressOps    = 1000000
+    stressCheck  = 10000           // ops between invariant checks
+    stressBudget = 5 * time.Second // generous; an O(n) Len or O(n^2) push blows well past it
+    stressWindow = 100             // list length kept by the pathological pattern
+)
+
+// TestStressMillionOps applies a million seeded operations and fails if the
+// run is slow enough to suggest an accidental complexity regression.
+func TestStressMillionOps(t *testing.T) {
+    if testing.Short() { t.Skip("slow: a million operations") }
+
+    t.Run("mixed", func(t *testing.T) {
+        g := opgen.New(modelSeed)
+        l := New()
+        start := time.Now()
+        for done := 0; done < stressOps; done += stressCheck {
+            for _, o := range randomOps(g, stressCheck) { apply(l, o); l.Len() }
+            if err := invariantErr(l); err != nil { t.Fatalf("after %d ops: %v", done+stressCheck, err) }
+        }
+        reportRate(t, start, stressOps)
+    })
+
+    // Pushing to the front while removing the last index keeps a fixed-size
+    // window and exercises the tail bookkeeping on every step.
+    t.Run("push-front-remove-last", func(t *testing.T) {
+        l := BuildFromRange(0, stressWindow, 1)
+        start := time.Now()
+        for i := 0; i < stressOps/2; i++ {
+            l.PushFront(stressWindow + i)
+            if !l.RemoveAt(l.Len() - 1) { t.Fatalf("op %d: RemoveAt(Len()-1) failed", i) }
+            if (i+1)%stressCheck == 0 {
+                if err := invariantErr(l); err != nil { t.Fatalf("after %d rounds: %v", i+1, err) }
+            }
+        }
+        reportRate(t, start, stressOps)
+        want := BuildFromRange(stressWindow+stressOps/2-1, stressOps/2-1, -1).ToSlice()
+        if got := l.ToSlice(); !reflect.DeepEqual(got, want) { t.Fatalf("window = %v..., want %v...", got[:3], want[:3]) }
+    })
+}
+
+// apply performs o on l without a model; the stress test checks structure only.
+func apply(l altimpl.List, o op) {
+    switch o.kind {
+    case opPushFront: l.PushFront(o.val)
+    case opPushBack: l.PushBack(o.val)
+    case opPopFront: l.PopFront()
+    case opInsertAt: l.InsertAt(o.idx, o.val)
+    case opRemoveAt: l.RemoveAt(o.idx)
+    case opClear: l.Clear()
+    }
+}
+
+func reportRate(t *testing.T, start time.Time, ops int) {
+    t.Helper()
+    elapsed := time.Since(start)
+    t.Logf("%d ops in %v (%.0f ops/sec)", ops, elapsed, float64(ops)/elapsed.Seconds())
+    if elapsed > stressBudget { t.Fatalf("%d ops took %v, budget %v", ops, elapsed, stressBudget) }
+}
@@ -0,0 +1,62 @@
+//go:build secret
+
+package main
+
+// Grading-only tasks live in task_secret_*.go files in secret/, outside
+// main/: the installer zips main/ into main.zip, which students can
+// download, so a file there would ship its source however it is tagged.
+// secret/ goes into makefile.zip instead. The secret build tag keeps them out
+// of a default build even when staged, so it neither runs nor lists them.
+// `make secret1` (task 6 in tasks.json) stages secret/ next to the driver and
+// builds with `go build -tags secret` to include them.
+
+func init() {
+    registerTask(driverTask{"secret1", "Secret1", []string{"start", "drain-then-push", "remove-last-then-push", "insert-end-then-push", "move-then-push"}, secret1_tail_ops})
+}
+
+// secret1_tail_ops replays operation sequences that leave a stale tail
+// pointer behind in common wrong implementations; each section ends with a
+// push to the back so the stale pointer shows up in the printed list.
+func secret1_tail_ops(r *taskRun) {
+    r.section(subtask("Secret1", "start"), "tail pointer stress")
+
+    r.section(subtask("Secret1", "drain-then-push"), "pop every element, then push")
+    lst := listOf(1, 2)
+    lst.PopFront()
+    lst.PopFront()
+    lst.PushBack(3)
+    b, ok := lst.Back()
+    r.printf("back=%d ok=%t\n", b, ok)
+    r.printList(lst, "after-drain-then-push")
+
+    r.section(subtask("Secret1", "remove-last-then-push"), "remove the last index twice, then push")
+    lst = listOf(1, 2, 3)
+    r.printf("ok=%t\n", lst.RemoveAt(lst.Len()-1))
+    r.printf("ok=%t\n", lst.RemoveAt(lst.Len()-1))
+    lst.PushBack(4)
+    b, _ = lst.Back()
+    r.printf("back=%d\n", b)
+    r.printList(lst, "after-remove-last-then-push")
+
+    r.section(subtask("Secret1", "insert-end-then-push"), "insert at index Len, then push")
+    lst = New()
+    r.printf("ok=%t\n", lst.InsertAt(lst.Len(), 5))
+    r.printf("ok=%t\n", lst.InsertAt(lst.Len(), 6))
+    lst.PushBack(7)
+    b, _ = lst.Back()
+    r.printf("back=%d\n", b)
+    r.printList(lst, "after-insert-end-then-push")
+
+    r.section(subtask("Secret1", "move-then-push"), "push to both lists after a move")
+    src := listOf(8, 9)
+    dst := MoveFrom(src)
+    src.PushBack(1)
+    dst.PushBack(10)
+    r.printList(src, "src")
+    r.printList(dst, "dst")
+    dst.MoveAssignFrom(src)
+    dst.PushBack(2)
+    src.PushBack(3)
+    r.printList(dst, "dst-after-assign")
+    r.printList(src, "src-after-assign")
+}
@@ -0,0 +1,65 @@
+// Spec skeleton (students implement these methods), generated from the
+// memo by tools/genspec.
+
+package main
+
+// ArrayList is a slice-backed list with the same core methods as LinkedList,
+// for comparing the two: indexing is O(1), but inserting or removing
+// anywhere but the back shifts every later value.
+type ArrayList struct {
+    data []int
+}
+
+// NewArrayList returns an empty ArrayList.
+func NewArrayList() *ArrayList { return &ArrayList{} }
+
+// NewWithCapacity returns an empty ArrayList with room for n values before
+// it has to grow; a negative n is treated as 0.
+func NewWithCapacity(n int) *ArrayList { panic(notImplemented("NewWithCapacity")) }
+
+// Len returns the number of values.
+func (a *ArrayList) Len() int { panic(notImplemented("ArrayList.Len")) }
+
+// IsEmpty reports whether the list holds no values.
+func (a *ArrayList) IsEmpty() bool { panic(notImplemented("ArrayList.IsEmpty")) }
+
+// Cap returns how many values the list can hold before it has to grow.
+func (a *ArrayList) Cap() int { panic(notImplemented("ArrayList.Cap")) }
+
+// Clear removes every value but keeps the capacity for reuse.
+func (a *ArrayList) Clear() { panic(notImplemented("ArrayList.Clear")) }
+
+// PushFront inserts v before the first value, shifting every value along.
+func (a *ArrayList) PushFront(v int) { panic(notImplemented("ArrayList.PushFront")) }
+
+// PushBack appends v after the last value in amortised O(1).
+func (a *ArrayList) PushBack(v int) { panic(notImplemented("ArrayList.PushBack")) }
+
+// PopFront removes the first value and returns true and that value, or
+// (false, 0) when the list is empty.
+func (a *ArrayList) PopFront() (bool, int) { panic(notImplemented("ArrayList.PopFront")) }
+
+// Front returns the first value and true, or (0, false) when the list is
+// empty.
+func (a *ArrayList) Front() (int, bool) { panic(notImplemented("ArrayList.Front")) }
+
+// Back returns the last value and true, or (0, false) when the list is
+// empty.
+func (a *ArrayList) Back() (int, bool) { panic(notImplemented("ArrayList.Back")) }
+
+// At returns the value at index idx and true in O(1), or (0, false) when idx
+// is outside [0, Len).
+func (a *ArrayList) At(idx int) (int, bool) { panic(notImplemented("ArrayList.At")) }
+
+// InsertAt inserts v so that it ends up at index idx and reports true.
+// idx may equal Len, which appends; a negative idx or one past Len leaves
+// the list unchanged and reports false.
+func (a *ArrayList) InsertAt(idx int, v int) bool { panic(notImplemented("ArrayList.InsertAt")) }
+
+// RemoveAt removes the value at index idx and reports true, or reports
+// false and leaves the list unchanged when idx is outside [0, Len).
+func (a *ArrayList) RemoveAt(idx int) bool { panic(notImplemented("ArrayList.RemoveAt")) }
+
+// ToSlice returns the values from front to back in a new slice; an empty
+// list gives an empty, non-nil slice.
+func (a *ArrayList) ToSlice() []int { panic(notImplemented("ArrayList.ToSlice")) }
@@ -0,0 +1,3 @@
+module github.com/COS301-SE-2025/Advanced-FitchFork/backend/api/assets/starters/go-linkedlist/spec
+
+go 1.27
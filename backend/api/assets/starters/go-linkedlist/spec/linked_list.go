@@ -1,33 +1,437 @@
+// Spec skeleton (students implement these methods), generated from the
+// memo by tools/genspec.
+
 package main
 
-// Spec skeleton (students implement these methods)
+import (
+    "errors"
+    "sync"
+)
 
 type node struct {
     val  int
     next *node
 }
 
+// LinkedList is a singly linked list of ints. The zero value is an empty
+// list; head and tail are nil exactly when size is 0, and sum is the
+// checksum of the values (see Checksum).
 type LinkedList struct {
     head *node
     tail *node
     size int
+    sum  uint64
+}
+
+// checksumOf is what each value adds to a list's checksum: v scrambled by
+// the SplitMix64 finaliser, so that lists whose plain sums agree still get
+// different checksums. It is a variable so tests can force collisions.
+var checksumOf = func(v int) uint64 {
+    x := uint64(v) + 0x9e3779b97f4a7c15
+    x = (x ^ x>>30) * 0xbf58476d1ce4e5b9
+    x = (x ^ x>>27) * 0x94d049bb133111eb
+    return x ^ x>>31
 }
 
+// ListAPI is declared in list_api.go, a copy of the driver's. This assertion
+// makes a changed signature a compile error here, naming ListAPI and the
+// method, rather than a failed build of the driver; keep it as it is.
+var _ ListAPI = (*LinkedList)(nil)
+
+// New returns an empty list.
 func New() *LinkedList { return &LinkedList{} }
+
+// Len returns the number of values in O(1).
 func (l *LinkedList) Len() int { return l.size }
+
+// IsEmpty reports whether the list holds no values.
 func (l *LinkedList) IsEmpty() bool { return l.size == 0 }
 
-func (l *LinkedList) Clear() { panic("TODO: Clear") }
-func (l *LinkedList) PushFront(v int) { panic("TODO: PushFront") }
-func (l *LinkedList) PushBack(v int) { panic("TODO: PushBack") }
-func (l *LinkedList) PopFront() (bool, int) { panic("TODO: PopFront") }
-func (l *LinkedList) Front() (int, bool) { panic("TODO: Front") }
-func (l *LinkedList) Back() (int, bool) { panic("TODO: Back") }
-func (l *LinkedList) InsertAt(idx int, v int) bool { panic("TODO: InsertAt") }
-func (l *LinkedList) RemoveAt(idx int) bool { panic("TODO: RemoveAt") }
-func (l *LinkedList) ToSlice() []int { panic("TODO: ToSlice") }
+// BuildFromRange returns a list of start, start+step, ... stopping before
+// end, like a half-open range. A negative step counts down; a step of 0, or
+// one that moves away from end, gives an empty list.
+func BuildFromRange(start, end, step int) *LinkedList { panic(notImplemented("BuildFromRange")) }
+
+// Clear removes every value, leaving an empty list that can be reused.
+func (l *LinkedList) Clear() { panic(notImplemented("Clear")) }
+
+// PushFront inserts v before the first value in O(1).
+func (l *LinkedList) PushFront(v int) { panic(notImplemented("PushFront")) }
+
+// PushBack appends v after the last value in O(1).
+func (l *LinkedList) PushBack(v int) { panic(notImplemented("PushBack")) }
+
+// PopFront removes the first value and returns true and that value, or
+// (false, 0) when the list is empty.
+func (l *LinkedList) PopFront() (bool, int) { panic(notImplemented("PopFront")) }
+
+// Front returns the first value and true, or (0, false) when the list is
+// empty.
+func (l *LinkedList) Front() (int, bool) { panic(notImplemented("Front")) }
+
+// Back returns the last value and true in O(1), or (0, false) when the list
+// is empty.
+func (l *LinkedList) Back() (int, bool) { panic(notImplemented("Back")) }
+
+// InsertAt inserts v so that it ends up at index idx and reports true.
+// idx may equal Len, which appends; a negative idx or one past Len leaves
+// the list unchanged and reports false.
+func (l *LinkedList) InsertAt(idx int, v int) bool { panic(notImplemented("InsertAt")) }
+
+// RemoveAt removes the value at index idx and reports true, or reports
+// false and leaves the list unchanged when idx is outside [0, Len).
+func (l *LinkedList) RemoveAt(idx int) bool { panic(notImplemented("RemoveAt")) }
+
+// RemoveLast removes the last node holding v and reports whether there was
+// one. A single pass remembers the predecessor of the latest match.
+func (l *LinkedList) RemoveLast(v int) bool { panic(notImplemented("RemoveLast")) }
+
+// EnforceMaxLength trims the list to at most n values, dropping the excess
+// from the front when dropFront is true and from the back otherwise. A
+// negative n empties the list; a list no longer than n is left unchanged.
+func (l *LinkedList) EnforceMaxLength(n int, dropFront bool) {
+    panic(notImplemented("EnforceMaxLength"))
+}
+
+// ToSlice returns the values from front to back in a new slice; an empty
+// list gives an empty, non-nil slice.
+func (l *LinkedList) ToSlice() []int { panic(notImplemented("ToSlice")) }
+
+// Do calls fn with each value from front to back until fn returns false. It
+// allocates nothing, unlike ToSlice.
+func (l *LinkedList) Do(fn func(int) bool) { panic(notImplemented("Do")) }
+
+// Iterator walks a list one value at a time. Before the first Next it holds
+// the list; after that only its current node, so nodes it has passed can be
+// collected once nothing else refers to them. Clear unlinks every node, so
+// an iterator over a cleared list simply ends.
+type Iterator struct {
+    l   *LinkedList // until the first Next
+    cur *node
+}
+
+// Iterator returns an iterator positioned before the first value.
+func (l *LinkedList) Iterator() *Iterator { panic(notImplemented("Iterator")) }
+
+// Next moves to the next value and reports whether there is one.
+func (it *Iterator) Next() bool { panic(notImplemented("Iterator.Next")) }
+
+// Value returns the current value: 0 before the first Next or after the end.
+func (it *Iterator) Value() int { panic(notImplemented("Iterator.Value")) }
+
+// ToSliceCapped returns at most max values from the front (none when max is
+// negative) and reports whether more were left out. It stops after max
+// values even if the list is corrupted into a cycle.
+func (l *LinkedList) ToSliceCapped(max int) ([]int, bool) { panic(notImplemented("ToSliceCapped")) }
+
+// PeekN returns copies of the first n values (all of them when n exceeds Len)
+// without changing the list.
+func (l *LinkedList) PeekN(n int) []int { panic(notImplemented("PeekN")) }
+
+// AsStringSlice formats each value with fmtFn, or strconv.Itoa when fmtFn is nil.
+func (l *LinkedList) AsStringSlice(fmtFn func(int) string) []string {
+    panic(notImplemented("AsStringSlice"))
+}
+
+// Copy returns a new list with the same values that shares no nodes with l.
+func (l *LinkedList) Copy() *LinkedList { panic(notImplemented("Copy")) }
+
+// Tee returns n independent copies of l, or nil when n <= 0.
+func (l *LinkedList) Tee(n int) []*LinkedList { panic(notImplemented("Tee")) }
+
+// CopyReversed returns a new list with l's values in reverse order. l is
+// not modified.
+func (l *LinkedList) CopyReversed() *LinkedList { panic(notImplemented("CopyReversed")) }
+
+// Frequencies returns each distinct value in ascending order and, at the
+// same index, how many times it occurs. An empty list gives two empty slices.
+func (l *LinkedList) Frequencies() (values []int, counts []int) { panic(notImplemented("Frequencies")) }
+
+// CountValuesExceeding returns how many values are strictly greater than
+// threshold, in one pass over the list.
+func (l *LinkedList) CountValuesExceeding(threshold int) int {
+    panic(notImplemented("CountValuesExceeding"))
+}
+
+// SumValuesExceeding returns the sum of the values strictly greater than
+// threshold, in one pass over the list; 0 when there are none.
+func (l *LinkedList) SumValuesExceeding(threshold int) int {
+    panic(notImplemented("SumValuesExceeding"))
+}
+
+// IsSorted reports whether the values are in non-decreasing order, in one
+// pass. Empty and single-value lists are sorted.
+func (l *LinkedList) IsSorted() bool { panic(notImplemented("IsSorted")) }
+
+// IsSortedDesc reports whether the values are in non-increasing order, in
+// one pass. Empty and single-value lists are sorted.
+func (l *LinkedList) IsSortedDesc() bool { panic(notImplemented("IsSortedDesc")) }
+
+// MaxGap returns the largest difference between neighbouring values once
+// they are sorted, with true: [3 6 9 1] sorts to [1 3 6 9] and gives 3. It
+// sorts a copy, so the list keeps its order. A list of fewer than two
+// values has no gap: 0, false.
+func (l *LinkedList) MaxGap() (int, bool) { panic(notImplemented("MaxGap")) }
+
+// SegmentSums splits the list into consecutive segments of the given lengths
+// and returns the sum of each, with true. It returns (nil, false) when a
+// length is negative or the lengths do not add up to Len. A zero length
+// gives a segment summing to 0, and an empty list with no lengths gives an
+// empty slice.
+func (l *LinkedList) SegmentSums(segmentLengths []int) ([]int, bool) {
+    panic(notImplemented("SegmentSums"))
+}
+
+// ScanLeft returns a new list of the running accumulator: element i is
+// fn applied across init and the first i+1 values. l is not modified.
+func (l *LinkedList) ScanLeft(init int, fn func(acc, v int) int) *LinkedList {
+    panic(notImplemented("ScanLeft"))
+}
+
+// MergeAlternating returns a new list taking values alternately from l and
+// other, starting with l; once either runs out the rest of the other follows.
+// Neither input is modified.
+func (l *LinkedList) MergeAlternating(other *LinkedList) *LinkedList {
+    panic(notImplemented("MergeAlternating"))
+}
+
+// Deinterleave splits l into two new lists, the values at even positions
+// (0, 2, ...) and those at odd positions, each in their original order; it
+// undoes MergeAlternating of two lists of equal length. l is not modified.
+func (l *LinkedList) Deinterleave() (evenPos, oddPos *LinkedList) {
+    panic(notImplemented("Deinterleave"))
+}
+
+// ToPairs returns each pair of adjacent values, front to back: [1 2 3]
+// gives [[1 2] [2 3]]. A list shorter than 2 gives an empty, non-nil slice.
+func (l *LinkedList) ToPairs() [][2]int { panic(notImplemented("ToPairs")) }
+
+// BucketBy groups the values by key(v) into new lists, keeping their original
+// order within each bucket. l is not modified.
+func (l *LinkedList) BucketBy(key func(int) int) map[int]*LinkedList {
+    panic(notImplemented("BucketBy"))
+}
+
+// ReplaceAll changes every value equal to old into new and returns how
+// many it changed.
+func (l *LinkedList) ReplaceAll(old, new int) int { panic(notImplemented("ReplaceAll")) }
+
+// ReplaceFirst changes the first value equal to old into new and reports
+// whether there was one.
+func (l *LinkedList) ReplaceFirst(old, new int) bool { panic(notImplemented("ReplaceFirst")) }
+
+// ApplyAt replaces the value at index idx with fn of it and reports true,
+// or reports false without calling fn when idx is outside [0, Len).
+func (l *LinkedList) ApplyAt(idx int, fn func(int) int) bool { panic(notImplemented("ApplyAt")) }
+
+// Clamp raises every value below lo to lo and lowers every value above hi to
+// hi, in place.
+func (l *LinkedList) Clamp(lo, hi int) { panic(notImplemented("Clamp")) }
+
+// NormalizeToRange rescales the values linearly in place so the smallest
+// becomes lo and the largest hi; hi may be below lo, which reverses the
+// order. Each value v becomes lo + (v-min)*(hi-lo)/(max-min), computed in
+// integers and rounded to the nearest int, with exact halves rounded away
+// from lo: [0 1 2] onto [0, 1] gives [0 1 1]. The product (v-min)*(hi-lo)
+// must fit in an int. An empty list, or one whose values are all equal, is
+// left unchanged.
+func (l *LinkedList) NormalizeToRange(lo, hi int) { panic(notImplemented("NormalizeToRange")) }
+
+// UniqueCounting collapses each run of equal adjacent values to its first
+// node and returns how many nodes it removed.
+func (l *LinkedList) UniqueCounting() int { panic(notImplemented("UniqueCounting")) }
+
+// RemoveIfAdjacentEqual removes both nodes of every adjacent pair holding
+// equal values, then repeats on the result until a pass removes nothing, so
+// [1 2 2 3 3 4] collapses to [1 4] and [1 2 3 3 2 4] does too. Unlike
+// UniqueCounting, no copy of a removed pair survives.
+func (l *LinkedList) RemoveIfAdjacentEqual() { panic(notImplemented("RemoveIfAdjacentEqual")) }
+
+// RemoveWhereIndex removes every node whose original index satisfies pred
+// and returns how many it removed.
+func (l *LinkedList) RemoveWhereIndex(pred func(index int) bool) int {
+    panic(notImplemented("RemoveWhereIndex"))
+}
+
+// InsertSortedUnique inserts v before the first larger value of an ascending
+// list and reports true, or leaves the list alone and reports false when v is
+// already present.
+func (l *LinkedList) InsertSortedUnique(v int) bool { panic(notImplemented("InsertSortedUnique")) }
+
+// ErrNotSorted is returned by PushBackSorted when v would break the order.
+var ErrNotSorted = errors.New("value is smaller than the back of the list")
+
+// PushBackSorted appends v when the list is empty or v >= Back(), keeping an
+// ascending list ascending; otherwise it returns ErrNotSorted and leaves the
+// list unchanged.
+func (l *LinkedList) PushBackSorted(v int) error { panic(notImplemented("PushBackSorted")) }
+
+// SwapPairs swaps each pair of adjacent nodes by relinking them, leaving an
+// odd last node in place: [1 2 3 4 5] becomes [2 1 4 3 5].
+func (l *LinkedList) SwapPairs() { panic(notImplemented("SwapPairs")) }
+
+// InterquartileTrim removes the outliers: values outside
+// [Q1 - 1.5*IQR, Q3 + 1.5*IQR]. Quartiles interpolate linearly between the
+// closest ranks of the sorted values (Q at p is at rank (n-1)*p, as in
+// numpy's default), so [1 2 3 4] has Q1 = 1.75 and Q3 = 3.25. Survivors keep
+// their order.
+func (l *LinkedList) InterquartileTrim() { panic(notImplemented("InterquartileTrim")) }
+
+// RotateUntilSorted relinks a rotated ascending list (non-decreasing, so
+// [3 4 5 1 2] qualifies) into sorted order and returns how many left
+// rotations that took. A list no rotation can sort is left as is: 0, false.
+func (l *LinkedList) RotateUntilSorted() (rotations int, ok bool) {
+    panic(notImplemented("RotateUntilSorted"))
+}
+
+// WindowMax returns the maximum of every run of k adjacent values, front to
+// back: Len-k+1 results, or an empty slice when k <= 0 or k > Len. It runs
+// in O(n) however large k is.
+func (l *LinkedList) WindowMax(k int) []int { panic(notImplemented("WindowMax")) }
+
+// SumRecursive adds the values with a recursive walk over the nodes. It is a
+// teaching reference only: recursion depth equals Len, so very long lists
+// cost a stack frame per node where a loop would run in constant space.
+func (l *LinkedList) SumRecursive() int { panic(notImplemented("SumRecursive")) }
+
+// CycleLength reports how many nodes form the cycle reachable from head, or
+// (0, false) when the chain ends in nil. A correct list never has a cycle;
+// this is for diagnosing corrupted ones, so it must not loop forever on one.
+func (l *LinkedList) CycleLength() (int, bool) { panic(notImplemented("CycleLength")) }
+
+// GapEncode returns the first value followed by the difference between each
+// value and the one before it; DecodeGaps reverses it.
+func (l *LinkedList) GapEncode() []int { panic(notImplemented("GapEncode")) }
+
+// DecodeGaps rebuilds the list GapEncode produced vs from.
+func DecodeGaps(vs []int) *LinkedList { panic(notImplemented("DecodeGaps")) }
+
+// Checksum returns a hash of the values that ignores their order. Every
+// method that adds, removes or changes a value keeps it up to date, so it
+// costs O(1). Lists holding the same values have the same checksum; other
+// lists almost always differ, but may collide.
+func (l *LinkedList) Checksum() uint64 { panic(notImplemented("Checksum")) }
+
+// Equal reports whether l and other hold the same values in the same order.
+// Lists of different sizes or checksums are rejected in O(1) without walking
+// either one, which settles the common unequal case however long the lists
+// are. Matching checksums do not prove equality, as they may collide or the
+// values may be in another order, so the lists are then compared value by
+// value and the answer is always exact.
+func (l *LinkedList) Equal(other *LinkedList) bool { panic(notImplemented("Equal")) }
+
+// EqualAsSet compares the distinct values of l and other, ignoring order and
+// repeats. It also returns the values only l holds and those only other
+// holds, each sorted ascending and nil when there are none, so feedback can
+// name them.
+func (l *LinkedList) EqualAsSet(other *LinkedList) (bool, []int, []int) {
+    panic(notImplemented("EqualAsSet"))
+}
+
+// Diff is one positional difference reported by DiffAgainst. Kind is
+// "mismatch" (both lists have Index, values differ), "missing" (only want has
+// it, Got is 0) or "extra" (only l has it, Want is 0).
+type Diff struct {
+    Index int    `json:"index"`
+    Got   int    `json:"got"`
+    Want  int    `json:"want"`
+    Kind  string `json:"kind"`
+}
+
+// DiffAgainst compares l with want position by position and returns every
+// difference in index order; nil means the lists are equal.
+func (l *LinkedList) DiffAgainst(want *LinkedList) []Diff { panic(notImplemented("DiffAgainst")) }
+
+// DiffJSON reports DiffAgainst(want) for the feedback service as
+// {"equal":bool,"diffs":[...]}; diffs is an empty array when equal.
+func (l *LinkedList) DiffJSON(want *LinkedList) ([]byte, error) { panic(notImplemented("DiffJSON")) }
+
+// MoveFrom returns a new list that takes over src's nodes without copying
+// them, and leaves src empty.
+func MoveFrom(src *LinkedList) *LinkedList { panic(notImplemented("MoveFrom")) }
+
+// MoveAssignFrom clears l, then takes over src's nodes without copying
+// them and leaves src empty.
+func (l *LinkedList) MoveAssignFrom(src *LinkedList) { panic(notImplemented("MoveAssignFrom")) }
+
+// dnode is one value of a DoublyLinkedList with links both ways.
+type dnode struct {
+    val        int
+    prev, next *dnode
+}
+
+// DoublyLinkedList is a list of ints linked in both directions, with a tail
+// pointer, so it can be walked from either end. The zero value is an empty
+// list ready to use.
+type DoublyLinkedList struct {
+    head, tail *dnode
+    size       int
+}
+
+// NewDoubly returns an empty DoublyLinkedList.
+func NewDoubly() *DoublyLinkedList { return &DoublyLinkedList{} }
+
+// Len returns the number of values in O(1).
+func (d *DoublyLinkedList) Len() int { return d.size }
+
+// PushBack appends v after the last value in O(1).
+func (d *DoublyLinkedList) PushBack(v int) { panic(notImplemented("DoublyLinkedList.PushBack")) }
+
+// InsertSorted inserts v into a list in ascending order so that it stays in
+// order, after any values equal to v. It walks in from both ends at once,
+// one step each, and inserts as soon as either side reaches v's place, so it
+// takes as many steps as v's distance from the nearer end: inserting near
+// the back is as cheap as near the front, which a singly linked list cannot
+// do. On an unsorted list v still goes in, at an unspecified index.
+func (d *DoublyLinkedList) InsertSorted(v int) {
+    panic(notImplemented("DoublyLinkedList.InsertSorted"))
+}
+
+// ToSlice returns the values from front to back, following next links; an
+// empty list gives an empty, non-nil slice.
+func (d *DoublyLinkedList) ToSlice() []int { panic(notImplemented("DoublyLinkedList.ToSlice")) }
+
+// ToSliceReverse returns the values from back to front, following prev
+// links, so it shows whether they agree with ToSlice.
+func (d *DoublyLinkedList) ToSliceReverse() []int {
+    panic(notImplemented("DoublyLinkedList.ToSliceReverse"))
+}
+
+// SafeList is a LinkedList guarded by a mutex for use from several
+// goroutines. PopFront checks and removes under one lock, so callers never
+// need the racy Front-then-PopFront pattern.
+type SafeList struct {
+    mu sync.Mutex
+    l  LinkedList
+}
+
+// NewSafeList returns an empty SafeList.
+func NewSafeList() *SafeList { return &SafeList{} }
+
+// Len returns the number of values.
+func (s *SafeList) Len() int { panic(notImplemented("SafeList.Len")) }
+
+// PushFront inserts v before the first value.
+func (s *SafeList) PushFront(v int) { panic(notImplemented("SafeList.PushFront")) }
+
+// PushBack appends v after the last value.
+func (s *SafeList) PushBack(v int) { panic(notImplemented("SafeList.PushBack")) }
+
+// PopFront removes and returns the first value as LinkedList.PopFront does,
+// holding the lock for both the check and the removal.
+func (s *SafeList) PopFront() (bool, int) { panic(notImplemented("SafeList.PopFront")) }
+
+// ToSlice returns a snapshot of the values from front to back.
+func (s *SafeList) ToSlice() []int { panic(notImplemented("SafeList.ToSlice")) }
+
+// notImplemented is the value the stubs panic with. The driver prints it as
+// a NOT IMPLEMENTED line in the section that called the stub, and marks the
+// task's remaining sections as not run instead of crashing with a stack
+// trace. Replace a stub's panic with your implementation.
+func notImplemented(name string) todoError { return todoError{name} }
 
-func (l *LinkedList) Copy() *LinkedList { panic("TODO: Copy") }
-func MoveFrom(src *LinkedList) *LinkedList { panic("TODO: MoveFrom") }
-func (l *LinkedList) MoveAssignFrom(src *LinkedList) { panic("TODO: MoveAssignFrom") }
+// todoError names the function or method that is not implemented yet.
+type todoError struct{ name string }
 
+func (e todoError) Error() string          { return "TODO: " + e.name }
+func (e todoError) NotImplemented() string { return e.name }
@@ -0,0 +1,25 @@
+package main
+
+// ListAPI lists the methods the driver needs from a list. It is declared
+// here with the driver, not in the linked_list.go students edit, so a
+// submission cannot change what it is checked against. memo/ and spec/ carry
+// byte-for-byte copies so each builds on its own, and linked_list.go asserts
+// that *LinkedList implements it, which turns a changed signature into a
+// compile error naming ListAPI and the method rather than a failed build of
+// the driver. Another implementation is graded through an adapter that
+// satisfies it (see memo/alt).
+type ListAPI interface {
+    Len() int
+    IsEmpty() bool
+    Clear()
+    PushFront(v int)
+    PushBack(v int)
+    PopFront() (bool, int)
+    Front() (int, bool)
+    Back() (int, bool)
+    InsertAt(idx int, v int) bool
+    RemoveAt(idx int) bool
+    ToSlice() []int
+    Copy() *LinkedList
+    MoveAssignFrom(src *LinkedList)
+}
@@ -0,0 +1,64 @@
+#!/bin/sh
+# Developer test runner for this starter (not shipped to students or the grader).
+#
+# The driver tests in main/ only compile next to a linked_list.go, exactly as
+# the grader lays the files out, so they run in a staging directory of
+# symlinks to main/ and memo/. testdata/ is linked too, so
+#   ./test.sh -run 'Golden|JSON' -update
+# rewrites the golden transcripts and JSON snapshots in place. Extra
+# arguments are passed to the driver tests only. The grading-only tasks in
+# secret/ are staged too and compile in only with the secret tag. So does
+# memo/alt's LinkedList on memo/altimpl's lists, which TestAltBuildsMatchGolden
+# builds in place of linked_list.go under each alternative's tag. The whole
+# driver suite then runs again in $build/alt, where memo/alt is the only
+# list, once per tag, so each alternative has to pass it too.
+#
+# The staging directory has no go.mod and builds with GO111MODULE=off, as
+# the grader's flat directory does. memo/altimpl is linked into a GOPATH
+# under it, at its module import path, so memo/alt's import resolves.
+# memo/ is a module of its own (see tools/modinit) and the root module holds
+# the tools and app/, the driver with the implementation picked by build tag;
+# both run in module mode. app/ is not committed: tools/syncmain generates it
+# from main/, memo/ and spec/ before the root module is built.
+#
+# The driver tests run first: tools/validate checks the golden transcripts,
+# so an -update has to land before the tools are tested.
+set -e
+cd "$(dirname "$0")"
+
+build=.build
+rm -rf "$build" && mkdir -p "$build"
+ln -s "$PWD"/main/*.go "$PWD"/memo/linked_list.go "$PWD"/memo/alt/*.go "$PWD"/secret/*.go "$build"/
+ln -s "$PWD"/main/testdata "$build"/testdata
+altimpl="$build/gopath/src/$(sed -n 's/^module //p' memo/go.mod)/altimpl"
+mkdir -p "$(dirname "$altimpl")" && ln -s "$PWD"/memo/altimpl "$altimpl"
+mkdir "$build/alt"
+ln -s "$PWD"/main/*.go "$PWD"/memo/alt/*.go "$build/alt"/
+ln -s "$PWD"/main/testdata "$build/alt"/testdata
+(
+    cd "$build"
+    export GO111MODULE=off GOPATH="$PWD/gopath"
+    go vet .
+    go test . "$@"
+    go test -race -run ParallelSafety .
+    cd alt
+    for tag in altslice altnotail; do
+        go vet -tags "$tag" .
+        go test -tags "$tag" .
+    done
+)
+
+go run ./tools/modinit -check
+go run ./tools/syncmain
+go build ./...
+go build -tags memo ./...
+go vet ./tools/... ./app
+go vet -tags memo ./app
+go test ./tools/...
+(
+    cd memo
+    go vet ./...
+    go test ./...
+    go test -race -run SafeList .
+    go test -run '^$' -fuzz=FuzzInsertRemove -fuzztime=200x .
+)
@@ -0,0 +1,216 @@
+// Command apicheck type-checks the spec skeleton and verifies that its
+// exported API matches the memo's: the same exported type names, variables,
+// constants, package-level functions and methods, with the same types, the
+// same parameter and result types and the same receiver kinds. A memo-only
+// addition otherwise produces a spec the driver cannot compile against, which
+// students only discover when they submit.
+//
+// The memo's ListAPI interface, its list_api.go copy of the driver's, is the
+// source of truth for what the driver needs: its methods are compared like any others, and a difference in a
+// LinkedList method it requires is marked "(required by ListAPI)", as the
+// driver will not build against that spec at all.
+//
+// Run it from the starter root:
+//
+//	go run ./tools/apicheck [-memo memo] [-spec spec]
+package main
+
+import (
+    "flag"
+    "fmt"
+    "go/ast"
+    "go/build"
+    "go/importer"
+    "go/parser"
+    "go/token"
+    "go/types"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+)
+
+func main() {
+    memoDir := flag.String("memo", "memo", "directory holding the memo implementation")
+    specDir := flag.String("spec", "spec", "directory holding the spec skeleton")
+    flag.Parse()
+
+    diffs, err := check(*memoDir, *specDir)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "apicheck:", err)
+        os.Exit(2)
+    }
+    for _, d := range diffs { fmt.Println(d) }
+    if len(diffs) > 0 { os.Exit(1) }
+    fmt.Println("apicheck: spec matches memo")
+}
+
+// check loads both directories and returns one line per API difference.
+func check(memoDir, specDir string) ([]string, error) {
+    memo, err := loadAPI(memoDir)
+    if err != nil { return nil, fmt.Errorf("memo: %w", err) }
+    spec, err := loadAPI(specDir)
+    if err != nil { return nil, fmt.Errorf("spec: %w", err) }
+    return diffAPI(memo, spec), nil
+}
+
+// apiInterface names the interface listing what the driver needs, and
+// apiType the type that must implement it.
+const (
+    apiInterface = "ListAPI"
+    apiType      = "LinkedList"
+)
+
+// valueReceiver marks the signature of a method declared on T rather than
+// *T: a student's *T method would not be in T's method set.
+const valueReceiver = " [value receiver]"
+
+// loadAPI type-checks the non-test Go files in dir and maps each exported
+// package-level function ("New") and method of an exported type
+// ("LinkedList.InsertAt") to its signature with parameter names dropped, e.g.
+// "(int, int) bool". Exported types are listed as "type Name" with no
+// signature, exported variables and constants as "var Name" and "const Name"
+// with their type. An exported interface's methods are listed like a type's
+// ("ListAPI.Len").
+func loadAPI(dir string) (map[string]string, error) {
+    pkg, err := build.ImportDir(dir, 0)
+    if err != nil { return nil, err }
+    fset := token.NewFileSet()
+    var files []*ast.File
+    for _, name := range pkg.GoFiles {
+        f, err := parser.ParseFile(fset, filepath.Join(dir, name), nil, 0)
+        if err != nil { return nil, err }
+        files = append(files, f)
+    }
+    conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+    tpkg, err := conf.Check(pkg.Name, fset, files, nil)
+    if err != nil { return nil, fmt.Errorf("does not compile: %w", err) }
+
+    api := map[string]string{}
+    scope := tpkg.Scope()
+    for _, name := range scope.Names() {
+        obj := scope.Lookup(name)
+        if !obj.Exported() { continue }
+        switch obj := obj.(type) {
+        case *types.Func:
+            api[name] = signature(obj.Type().(*types.Signature))
+        case *types.Var:
+            api["var "+name] = types.TypeString(obj.Type(), noQual)
+        case *types.Const:
+            api["const "+name] = types.TypeString(obj.Type(), noQual)
+        case *types.TypeName:
+            api["type "+name] = ""
+            if iface, ok := obj.Type().Underlying().(*types.Interface); ok {
+                for i := 0; i < iface.NumMethods(); i++ {
+                    if fn := iface.Method(i); fn.Exported() { api[name+"."+fn.Name()] = signature(fn.Type().(*types.Signature)) }
+                }
+                continue
+            }
+            mset := types.NewMethodSet(types.NewPointer(obj.Type()))
+            for i := 0; i < mset.Len(); i++ {
+                fn := mset.At(i).Obj().(*types.Func)
+                if !fn.Exported() { continue }
+                sig := fn.Type().(*types.Signature)
+                api[name+"."+fn.Name()] = signature(sig)
+                if _, ptr := sig.Recv().Type().(*types.Pointer); !ptr { api[name+"."+fn.Name()] += valueReceiver }
+            }
+        }
+    }
+    return api, nil
+}
+
+func noQual(*types.Package) string { return "" }
+
+// signature renders sig's parameter and result types without names or package qualifiers.
+func signature(sig *types.Signature) string {
+    tuple := func(t *types.Tuple, variadic bool) []string {
+        var parts []string
+        for i := 0; i < t.Len(); i++ {
+            s := types.TypeString(t.At(i).Type(), noQual)
+            if variadic && i == t.Len()-1 { s = "..." + strings.TrimPrefix(s, "[]") }
+            parts = append(parts, s)
+        }
+        return parts
+    }
+    s := "(" + strings.Join(tuple(sig.Params(), sig.Variadic()), ", ") + ")"
+    switch res := tuple(sig.Results(), false); len(res) {
+    case 0:
+    case 1: s += " " + res[0]
+    default: s += " (" + strings.Join(res, ", ") + ")"
+    }
+    return s
+}
+
+// diffAPI lists, in name order, what the spec is missing, what it adds and
+// where the signatures disagree.
+func diffAPI(memo, spec map[string]string) []string {
+    required := func(n string) string {
+        method, ok := strings.CutPrefix(n, apiType+".")
+        if _, inIface := memo[apiInterface+"."+method]; ok && inIface { return " (required by " + apiInterface + ")" }
+        return ""
+    }
+    names := map[string]bool{}
+    for n := range memo { names[n] = true }
+    for n := range spec { names[n] = true }
+    sorted := make([]string, 0, len(names))
+    for n := range names { sorted = append(sorted, n) }
+    sort.Strings(sorted)
+
+    var diffs []string
+    for _, n := range sorted {
+        m, inMemo := memo[n]
+        s, inSpec := spec[n]
+        switch {
+        case !inSpec: diffs = append(diffs, "missing in spec: "+entry(n, m)+required(n))
+        case !inMemo: diffs = append(diffs, "extra in spec:   "+entry(n, s))
+        case m != s: diffs = append(diffs, fmt.Sprintf("mismatch:        %s memo %s, spec %s%s%s", n, m, s, countNote(m, s), required(n)))
+        }
+    }
+    return diffs
+}
+
+// entry renders a name with its signature, "New() *LinkedList", or its
+// type, "var ErrNotSorted error".
+func entry(name, sig string) string {
+    if sig != "" && !strings.HasPrefix(sig, "(") { return name + " " + sig }
+    return name + sig
+}
+
+// countNote points out a differing number of parameters or results, which
+// is easy to miss when comparing two rendered signatures by eye.
+func countNote(memo, spec string) string {
+    if !strings.HasPrefix(memo, "(") || !strings.HasPrefix(spec, "(") { return "" }
+    memo, spec = strings.TrimSuffix(memo, valueReceiver), strings.TrimSuffix(spec, valueReceiver)
+    mp, mr := arity(memo)
+    sp, sr := arity(spec)
+    switch {
+    case mp != sp: return fmt.Sprintf(" (%d parameters vs %d)", mp, sp)
+    case mr != sr: return fmt.Sprintf(" (%d results vs %d)", mr, sr)
+    }
+    return ""
+}
+
+// arity counts the parameters and results of a signature rendered by signature.
+func arity(sig string) (params, results int) {
+    depth, end := 0, 0
+    for i, r := range sig {
+        if r == '(' { depth++ }
+        if r == ')' { depth--; if depth == 0 { end = i; break } }
+    }
+    count := func(s string) int {
+        s = strings.TrimSpace(s)
+        if s == "" { return 0 }
+        n, depth := 1, 0
+        for _, r := range s {
+            switch r {
+            case '(', '[', '{': depth++
+            case ')', ']', '}': depth--
+            case ',': if depth == 0 { n++ }
+            }
+        }
+        return n
+    }
+    res := strings.TrimSpace(sig[end+1:])
+    if strings.HasPrefix(res, "(") { res = res[1 : len(res)-1] }
+    return count(sig[1:end]), count(res)
+}
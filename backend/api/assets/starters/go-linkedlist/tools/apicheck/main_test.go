@@ -0,0 +1,144 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+func TestSpecMatchesMemo(t *testing.T) {
+    diffs, err := check(filepath.Join("..", "..", "memo"), filepath.Join("..", "..", "spec"))
+    if err != nil { t.Fatal(err) }
+    if len(diffs) > 0 { t.Fatalf("spec API differs from memo:\n%s", strings.Join(diffs, "\n")) }
+}
+
+func writePkg(t *testing.T, src string) string {
+    t.Helper()
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "linked_list.go"), []byte(src), 0o644); err != nil { t.Fatal(err) }
+    return dir
+}
+
+func TestDiffReportsEachMismatchClass(t *testing.T) {
+    memo := writePkg(t, `package main
+type LinkedList struct{ size int }
+func New() *LinkedList { return &LinkedList{} }
+func (l *LinkedList) Len() int { return l.size }
+func (l *LinkedList) PopFront() (bool, int) { return false, 0 }
+func (l *LinkedList) InsertAt(idx int, v int) bool { return false }
+`)
+    spec := writePkg(t, `package main
+type LinkedList struct{ size int }
+func New() *LinkedList { return &LinkedList{} }
+func (l *LinkedList) PopFront() (int, bool) { panic("TODO: PopFront") }
+func (l *LinkedList) InsertAt(i, value int) bool { panic("TODO: InsertAt") }
+func (l *LinkedList) Extra() { panic("TODO: Extra") }
+`)
+    diffs, err := check(memo, spec)
+    if err != nil { t.Fatal(err) }
+    want := []string{
+        "extra in spec:   LinkedList.Extra()",
+        "missing in spec: LinkedList.Len() int",
+        "mismatch:        LinkedList.PopFront memo () (bool, int), spec () (int, bool)",
+    }
+    if !reflect.DeepEqual(diffs, want) { t.Fatalf("diffs:\n%s\nwant:\n%s", strings.Join(diffs, "\n"), strings.Join(want, "\n")) }
+}
+
+func TestDiffReportsTypesAndCounts(t *testing.T) {
+    memo := writePkg(t, `package main
+type LinkedList struct{ size int }
+type Diff struct{ Index int }
+type SafeList struct{}
+func (s *SafeList) PopFront() (bool, int) { return false, 0 }
+func (l *LinkedList) Apply(fn func(int) int, idx int) bool { return false }
+`)
+    spec := writePkg(t, `package main
+type LinkedList struct{ size int }
+type SafeList struct{}
+type Extra int
+func (s *SafeList) PopFront() bool { panic("TODO: SafeList.PopFront") }
+func (l *LinkedList) Apply(fn func(int) int) bool { panic("TODO: Apply") }
+`)
+    diffs, err := check(memo, spec)
+    if err != nil { t.Fatal(err) }
+    want := []string{
+        "mismatch:        LinkedList.Apply memo (func(int) int, int) bool, spec (func(int) int) bool (2 parameters vs 1)",
+        "mismatch:        SafeList.PopFront memo () (bool, int), spec () bool (2 results vs 1)",
+        "missing in spec: type Diff",
+        "extra in spec:   type Extra",
+    }
+    if !reflect.DeepEqual(diffs, want) { t.Fatalf("diffs:\n%s\nwant:\n%s", strings.Join(diffs, "\n"), strings.Join(want, "\n")) }
+}
+
+func TestSpecMustCompile(t *testing.T) {
+    memo := writePkg(t, "package main\ntype LinkedList struct{}\n")
+    spec := writePkg(t, "package main\ntype LinkedList struct{}\nfunc (l *LinkedList) Len() int { return l.missing }\n")
+    if _, err := check(memo, spec); err == nil || !strings.Contains(err.Error(), "does not compile") {
+        t.Fatalf("expected a compile error for the spec, got %v", err)
+    }
+}
+
+// TestFixtures checks each spec package under testdata against
+// testdata/memo; each fixture differs from it in one way.
+func TestFixtures(t *testing.T) {
+    cases := []struct {
+        spec string
+        want []string
+    }{
+        {"missing_method", []string{"missing in spec: LinkedList.PopFront() (bool, int)"}},
+        {"param_type", []string{"mismatch:        LinkedList.InsertAt memo (int, int) bool, spec (int, int64) bool"}},
+        {"extra_func", []string{"extra in spec:   Reverse(*LinkedList) *LinkedList"}},
+        {"value_receiver", []string{"mismatch:        LinkedList.Len memo () int, spec () int [value receiver]"}},
+        {"var_type", []string{"mismatch:        var ErrEmpty memo error, spec string"}},
+    }
+    for _, c := range cases {
+        diffs, err := check(filepath.Join("testdata", "memo"), filepath.Join("testdata", c.spec))
+        if err != nil { t.Fatalf("%s: %v", c.spec, err) }
+        if !reflect.DeepEqual(diffs, c.want) { t.Errorf("%s: diffs:\n%s\nwant:\n%s", c.spec, strings.Join(diffs, "\n"), strings.Join(c.want, "\n")) }
+    }
+}
+
+// TestInterfaceIsTheSourceOfTruth checks that ListAPI's methods are compared
+// and that differences in the LinkedList methods it requires are marked.
+func TestInterfaceIsTheSourceOfTruth(t *testing.T) {
+    memo := writePkg(t, `package main
+type ListAPI interface {
+    Len() int
+    PopFront() (bool, int)
+}
+type LinkedList struct{ size int }
+func (l *LinkedList) Len() int { return l.size }
+func (l *LinkedList) PopFront() (bool, int) { return false, 0 }
+func (l *LinkedList) Extra() {}
+`)
+    spec := writePkg(t, `package main
+type ListAPI interface {
+    Len() int
+    PopFront() (int, bool)
+}
+type LinkedList struct{ size int }
+func (l *LinkedList) PopFront() (int, bool) { panic("TODO: PopFront") }
+`)
+    diffs, err := check(memo, spec)
+    if err != nil { t.Fatal(err) }
+    want := []string{
+        "missing in spec: LinkedList.Extra()",
+        "missing in spec: LinkedList.Len() int (required by ListAPI)",
+        "mismatch:        LinkedList.PopFront memo () (bool, int), spec () (int, bool) (required by ListAPI)",
+        "mismatch:        ListAPI.PopFront memo () (bool, int), spec () (int, bool)",
+    }
+    if !reflect.DeepEqual(diffs, want) { t.Fatalf("diffs:\n%s\nwant:\n%s", strings.Join(diffs, "\n"), strings.Join(want, "\n")) }
+}
+
+// TestWrongSignatureNamesTheInterface type-checks testdata/wrong_signature,
+// a spec whose PushBack takes a string: the error a student sees names
+// ListAPI and the method, with the signature it has and the one it needs.
+func TestWrongSignatureNamesTheInterface(t *testing.T) {
+    _, err := check(filepath.Join("testdata", "memo"), filepath.Join("testdata", "wrong_signature"))
+    if err == nil { t.Fatal("the spec with a wrong signature compiled") }
+    for _, want := range []string{"*LinkedList does not implement ListAPI (wrong type for method PushBack)", "have PushBack(string)", "want PushBack(int)"} {
+        if !strings.Contains(err.Error(), want) { t.Errorf("error %q does not mention %q", err, want) }
+    }
+}
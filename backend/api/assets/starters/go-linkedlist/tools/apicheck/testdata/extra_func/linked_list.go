@@ -0,0 +1,13 @@
+package main
+
+import "errors"
+
+var ErrEmpty = errors.New("empty list")
+
+type LinkedList struct{ size int }
+
+func New() *LinkedList { return &LinkedList{} }
+func (l *LinkedList) Len() int { return l.size }
+func (l *LinkedList) InsertAt(idx int, v int) bool { panic("TODO: InsertAt") }
+func (l *LinkedList) PopFront() (bool, int) { panic("TODO: PopFront") }
+func Reverse(l *LinkedList) *LinkedList { panic("TODO: Reverse") }
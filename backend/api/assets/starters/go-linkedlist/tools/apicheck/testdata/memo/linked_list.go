@@ -0,0 +1,14 @@
+// Package main is the reference API the spec fixtures beside it are checked
+// against.
+package main
+
+import "errors"
+
+var ErrEmpty = errors.New("empty list")
+
+type LinkedList struct{ size int }
+
+func New() *LinkedList { return &LinkedList{} }
+func (l *LinkedList) Len() int { return l.size }
+func (l *LinkedList) InsertAt(idx int, v int) bool { return false }
+func (l *LinkedList) PopFront() (bool, int) { return false, 0 }
@@ -0,0 +1,14 @@
+package main
+
+type ListAPI interface {
+    Len() int
+    PushBack(v int)
+}
+
+var _ ListAPI = (*LinkedList)(nil)
+
+type LinkedList struct{ size int }
+
+func New() *LinkedList { return &LinkedList{} }
+func (l *LinkedList) Len() int { return l.size }
+func (l *LinkedList) PushBack(v string) { panic("TODO: PushBack") }
@@ -0,0 +1,209 @@
+// Command bundle assembles what students are handed: the driver from main/,
+// the skeleton from spec/ and the Makefile, flat in one directory or zip as
+// the grader lays them out. It leaves out
+//
+//   - test files and subdirectories such as main/testdata/,
+//   - the go.mod files tools/modinit keeps in main/ and spec/, as the grader
+//     builds the flat directory without one,
+//   - spec/ files identical to a main/ file of the same name, such as the
+//     list_api.go copy,
+//   - files that only build with the secret tag (grading-only tasks), and
+//   - files marked grading-only with an //ff:internal line in the comments
+//     above their package clause.
+//
+// Marked files stay in main/, so the grading build still has them; they must
+// hook in from init, as nothing else in the bundle can refer to them. The
+// bundle ends with MANIFEST.txt listing every file it holds, its SHA-256 and
+// where it came from.
+//
+// Run it from the starter root:
+//
+//	go run ./tools/bundle [-root .] -out student-bundle[.zip]
+//
+// An -out ending in .zip gets a zip archive, anything else a new directory.
+package main
+
+import (
+    "archive/zip"
+    "bytes"
+    "crypto/sha256"
+    "flag"
+    "fmt"
+    "go/build/constraint"
+    "go/parser"
+    "go/token"
+    "os"
+    "path/filepath"
+    "sort"
+    "strings"
+)
+
+// internalMarker marks a grading-only file.
+const internalMarker = "//ff:internal"
+
+// manifestName is the manifest's file name inside the bundle.
+const manifestName = "MANIFEST.txt"
+
+// sourceDirs are the directories whose files go into the bundle, in order.
+var sourceDirs = []string{"main", "spec"}
+
+// entry is a file in the bundle: its name there and its path under the root.
+type entry struct{ name, src string }
+
+// skip is a file or directory left out of the bundle, and why.
+type skip struct{ src, reason string }
+
+func main() {
+    root := flag.String("root", ".", "starter root holding main/, spec/ and makefile/")
+    out := flag.String("out", "", "bundle to create: a directory, or a zip archive if it ends in .zip")
+    flag.Parse()
+    if *out == "" {
+        fmt.Fprintln(os.Stderr, "usage: bundle [-root .] -out dir|file.zip")
+        os.Exit(2)
+    }
+
+    entries, skipped, err := collect(*root)
+    if err == nil { err = write(*root, *out, entries) }
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "bundle:", err)
+        os.Exit(2)
+    }
+    for _, s := range skipped { fmt.Printf("left out %s: %s\n", s.src, s.reason) }
+    fmt.Printf("bundle: wrote %d files to %s\n", len(entries)+1, *out)
+}
+
+// collect lists the files that go into the bundle, in bundle order, and the
+// ones left out.
+func collect(root string) ([]entry, []skip, error) {
+    var entries []entry
+    var skipped []skip
+    seen := map[string]string{}
+    add := func(name, src string) error {
+        if prev, dup := seen[name]; dup {
+            same, err := sameFile(filepath.Join(root, prev), filepath.Join(root, src))
+            if err != nil { return err }
+            if !same { return fmt.Errorf("%s and %s would both be %s in the bundle", prev, src, name) }
+            skipped = append(skipped, skip{src, "same as " + prev})
+            return nil
+        }
+        seen[name] = src
+        entries = append(entries, entry{name, src})
+        return nil
+    }
+    for _, dir := range sourceDirs {
+        files, err := os.ReadDir(filepath.Join(root, dir))
+        if err != nil { return nil, nil, err }
+        for _, f := range files {
+            src := filepath.Join(dir, f.Name())
+            reason, err := exclude(filepath.Join(root, src), f)
+            if err != nil { return nil, nil, err }
+            if reason != "" { skipped = append(skipped, skip{src, reason}); continue }
+            if err := add(f.Name(), src); err != nil { return nil, nil, err }
+        }
+    }
+    if err := add("Makefile", filepath.Join("makefile", "Makefile")); err != nil { return nil, nil, err }
+    return entries, skipped, nil
+}
+
+// sameFile reports whether the files at a and b hold the same bytes.
+func sameFile(a, b string) (bool, error) {
+    x, err := os.ReadFile(a)
+    if err != nil { return false, err }
+    y, err := os.ReadFile(b)
+    if err != nil { return false, err }
+    return bytes.Equal(x, y), nil
+}
+
+// exclude says why the file at path is left out of the bundle, or returns ""
+// to keep it.
+func exclude(path string, f os.DirEntry) (string, error) {
+    switch {
+    case f.IsDir() && f.Name() == "testdata": return "test data", nil
+    case f.IsDir(): return "subdirectory", nil
+    case strings.HasSuffix(f.Name(), "_test.go"): return "test file", nil
+    case f.Name() == "go.mod" || f.Name() == "go.sum": return "module file", nil
+    case !strings.HasSuffix(f.Name(), ".go"): return "", nil
+    }
+    file, err := parser.ParseFile(token.NewFileSet(), path, nil, parser.PackageClauseOnly|parser.ParseComments)
+    if err != nil { return "", err }
+    for _, group := range file.Comments {
+        if group.Pos() > file.Package { break }
+        for _, c := range group.List {
+            if c.Text == internalMarker { return "marked " + internalMarker, nil }
+            if !constraint.IsGoBuild(c.Text) { continue }
+            expr, err := constraint.Parse(c.Text)
+            if err != nil { return "", fmt.Errorf("%s: %w", path, err) }
+            if secretOnly(expr) { return "builds only with the secret tag", nil }
+        }
+    }
+    return "", nil
+}
+
+// secretOnly reports whether expr is false for every combination of its tags
+// in which secret is not set.
+func secretOnly(expr constraint.Expr) bool {
+    var tags []string
+    expr.Eval(func(tag string) bool {
+        if tag != "secret" { tags = append(tags, tag) }
+        return false
+    })
+    for set := 0; set < 1<<len(tags); set++ {
+        on := func(tag string) bool {
+            for i, t := range tags {
+                if t == tag { return set&(1<<i) != 0 }
+            }
+            return false
+        }
+        if expr.Eval(on) { return false }
+    }
+    return true
+}
+
+// write creates the bundle at out: a zip archive when out ends in .zip, a
+// new directory otherwise. Either way it ends with the manifest.
+func write(root, out string, entries []entry) error {
+    if _, err := os.Stat(out); err == nil { return fmt.Errorf("%s already exists", out) }
+    files := map[string][]byte{}
+    for _, e := range entries {
+        data, err := os.ReadFile(filepath.Join(root, e.src))
+        if err != nil { return err }
+        files[e.name] = data
+    }
+    files[manifestName] = manifest(entries, files)
+    names := append(sortedNames(entries), manifestName)
+
+    if !strings.HasSuffix(out, ".zip") {
+        if err := os.MkdirAll(out, 0o755); err != nil { return err }
+        for _, name := range names {
+            if err := os.WriteFile(filepath.Join(out, name), files[name], 0o644); err != nil { return err }
+        }
+        return nil
+    }
+    f, err := os.Create(out)
+    if err != nil { return err }
+    zw := zip.NewWriter(f)
+    for _, name := range names {
+        w, err := zw.Create(name)
+        if err == nil { _, err = w.Write(files[name]) }
+        if err != nil { f.Close(); return err }
+    }
+    if err := zw.Close(); err != nil { f.Close(); return err }
+    return f.Close()
+}
+
+func sortedNames(entries []entry) []string {
+    names := make([]string, len(entries))
+    for i, e := range entries { names[i] = e.name }
+    sort.Strings(names)
+    return names
+}
+
+// manifest lists the bundled files one per line in name order: SHA-256,
+// name in the bundle and the path it was copied from.
+func manifest(entries []entry, files map[string][]byte) []byte {
+    src := map[string]string{}
+    for _, e := range entries { src[e.name] = filepath.ToSlash(e.src) }
+    var b strings.Builder
+    for _, name := range sortedNames(entries) { fmt.Fprintf(&b, "%x  %s  %s\n", sha256.Sum256(files[name]), name, src[name]) }
+    return []byte(b.String())
+}
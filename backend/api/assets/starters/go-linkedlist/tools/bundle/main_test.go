@@ -0,0 +1,182 @@
+package main
+
+import (
+    "archive/zip"
+    "go/build/constraint"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "reflect"
+    "sort"
+    "strings"
+    "testing"
+)
+
+// bundleDir bundles root into a new directory and returns it.
+func bundleDir(t *testing.T, root string) string {
+    t.Helper()
+    entries, _, err := collect(root)
+    if err != nil { t.Fatal(err) }
+    out := filepath.Join(t.TempDir(), "bundle")
+    if err := write(root, out, entries); err != nil { t.Fatal(err) }
+    return out
+}
+
+func names(t *testing.T, dir string) []string {
+    t.Helper()
+    files, err := os.ReadDir(dir)
+    if err != nil { t.Fatal(err) }
+    var out []string
+    for _, f := range files { out = append(out, f.Name()) }
+    return out
+}
+
+func goBuild(t *testing.T, dir string) {
+    t.Helper()
+    cmd := exec.Command("go", "build", "-o", filepath.Join(t.TempDir(), "app"), ".")
+    cmd.Dir = dir
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if out, err := cmd.CombinedOutput(); err != nil { t.Fatalf("bundle does not build: %v\n%s", err, out) }
+}
+
+// TestMainHoldsNoSecretTasks checks that no grading-only file sits in main/:
+// the installer zips main/ into main.zip skipping only tests and testdata/,
+// so secret tasks belong in secret/, which it puts in makefile.zip.
+func TestMainHoldsNoSecretTasks(t *testing.T) {
+    dir := filepath.Join("..", "..", "main")
+    files, err := os.ReadDir(dir)
+    if err != nil { t.Fatal(err) }
+    for _, f := range files {
+        reason, err := exclude(filepath.Join(dir, f.Name()), f)
+        if err != nil { t.Fatal(err) }
+        if reason == "builds only with the secret tag" { t.Errorf("main/%s %s; move it to secret/", f.Name(), reason) }
+    }
+}
+
+// TestStarterBundle bundles this starter: the result must build on its own
+// and hold no tests, test data, go.mod, secret tasks or marked files, and the
+// manifest must list exactly the other files.
+func TestStarterBundle(t *testing.T) {
+    root := filepath.Join("..", "..")
+    out := bundleDir(t, root)
+    goBuild(t, out)
+
+    var listed []string
+    held := map[string]bool{}
+    for _, name := range names(t, out) {
+        if name == manifestName { continue }
+        listed = append(listed, name)
+        held[name] = true
+        data, err := os.ReadFile(filepath.Join(out, name))
+        if err != nil { t.Fatal(err) }
+        switch {
+        case strings.HasSuffix(name, "_test.go"), name == "testdata", name == "go.mod": t.Errorf("bundle holds %s", name)
+        case strings.Contains(string(data), "\n"+internalMarker+"\n"), strings.HasPrefix(string(data), internalMarker+"\n"): t.Errorf("bundle holds %s, marked %s", name, internalMarker)
+        case strings.Contains(string(data), "//go:build secret"): t.Errorf("bundle holds the secret-only %s", name)
+        }
+    }
+    for _, want := range []string{"main.go", "linked_list.go", "Makefile"} {
+        if !held[want] { t.Errorf("bundle is missing %s", want) }
+    }
+    manifest, err := os.ReadFile(filepath.Join(out, manifestName))
+    if err != nil { t.Fatal(err) }
+    var inManifest []string
+    for _, line := range strings.Split(strings.TrimSuffix(string(manifest), "\n"), "\n") { inManifest = append(inManifest, strings.Fields(line)[1]) }
+    if !reflect.DeepEqual(inManifest, listed) { t.Errorf("manifest lists %v, bundle holds %v", inManifest, listed) }
+}
+
+func writeTree(t *testing.T, files map[string]string) string {
+    t.Helper()
+    root := t.TempDir()
+    for name, src := range files {
+        path := filepath.Join(root, filepath.FromSlash(name))
+        if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { t.Fatal(err) }
+        if err := os.WriteFile(path, []byte(src), 0o644); err != nil { t.Fatal(err) }
+    }
+    return root
+}
+
+// TestInternalFilesLeftOut plants a marked file that the grading build
+// compiles (it hooks in through init) and checks that the bundle leaves it
+// out yet still builds, as a directory and as a zip.
+func TestInternalFilesLeftOut(t *testing.T) {
+    root := writeTree(t, map[string]string{
+        "main/main.go":          "package main\n\nvar hooks []func()\n\nfunc main() { for _, h := range hooks { h() } }\n",
+        "main/counters.go":      "// Operation counters for grading runs.\n//\n" + internalMarker + "\n\npackage main\n\nfunc init() { hooks = append(hooks, func() {}) }\n",
+        "main/late.go":          "package main\n\n" + internalMarker + "\n",
+        "main/main_test.go":     "package main\n",
+        "main/testdata/x.txt":   "x\n",
+        "main/task_secret_x.go": "//go:build secret\n\npackage main\n",
+        "spec/linked_list.go":   "package main\n",
+        "makefile/Makefile":     "build:\n",
+    })
+    _, skipped, err := collect(root)
+    if err != nil { t.Fatal(err) }
+    want := []skip{
+        {filepath.Join("main", "counters.go"), "marked " + internalMarker},
+        {filepath.Join("main", "main_test.go"), "test file"},
+        {filepath.Join("main", "task_secret_x.go"), "builds only with the secret tag"},
+        {filepath.Join("main", "testdata"), "test data"},
+    }
+    if !reflect.DeepEqual(skipped, want) { t.Fatalf("left out %v, want %v", skipped, want) }
+
+    out := bundleDir(t, root)
+    if got, want := names(t, out), []string{manifestName, "Makefile", "late.go", "linked_list.go", "main.go"}; !reflect.DeepEqual(got, want) {
+        t.Fatalf("bundle holds %v, want %v (a marker after the package clause does not count)", got, want)
+    }
+    goBuild(t, out)
+
+    entries, _, _ := collect(root)
+    archive := filepath.Join(t.TempDir(), "bundle.zip")
+    if err := write(root, archive, entries); err != nil { t.Fatal(err) }
+    zr, err := zip.OpenReader(archive)
+    if err != nil { t.Fatal(err) }
+    defer zr.Close()
+    var zipped []string
+    for _, f := range zr.File { zipped = append(zipped, f.Name) }
+    sort.Strings(zipped)
+    if want := names(t, out); !reflect.DeepEqual(zipped, want) { t.Errorf("zip holds %v, directory bundle %v", zipped, want) }
+
+    if err := write(root, out, entries); err == nil { t.Error("write overwrote an existing bundle") }
+}
+
+func TestCollectRejectsNameClash(t *testing.T) {
+    root := writeTree(t, map[string]string{
+        "main/linked_list.go": "package main\n",
+        "spec/linked_list.go": "package main\n\nfunc New() {}\n",
+        "makefile/Makefile":   "build:\n",
+    })
+    if _, _, err := collect(root); err == nil || !strings.Contains(err.Error(), "would both be linked_list.go") { t.Fatalf("collect err = %v, want a name clash", err) }
+}
+
+// TestCollectKeepsOneCopy checks that a spec/ file identical to main/'s, as
+// list_api.go is, goes into the bundle once.
+func TestCollectKeepsOneCopy(t *testing.T) {
+    root := writeTree(t, map[string]string{
+        "main/list_api.go":    "package main\n\ntype ListAPI interface{}\n",
+        "spec/list_api.go":    "package main\n\ntype ListAPI interface{}\n",
+        "spec/linked_list.go": "package main\n",
+        "makefile/Makefile":   "build:\n",
+    })
+    entries, skipped, err := collect(root)
+    if err != nil { t.Fatal(err) }
+    if want := []skip{{filepath.Join("spec", "list_api.go"), "same as " + filepath.Join("main", "list_api.go")}}; !reflect.DeepEqual(skipped, want) { t.Errorf("left out %v, want %v", skipped, want) }
+    if got, want := sortedNames(entries), []string{"Makefile", "linked_list.go", "list_api.go"}; !reflect.DeepEqual(got, want) { t.Errorf("bundle holds %v, want %v", got, want) }
+}
+
+func TestSecretOnly(t *testing.T) {
+    cases := map[string]bool{
+        "secret":                    true,
+        "secret && memo":            true,
+        "(memo || !memo) && secret": true,
+        "secret || memo":            false,
+        "!secret":                   false,
+        "memo":                      false,
+        "!memo":                     false,
+    }
+    for src, want := range cases {
+        expr, err := constraint.Parse("//go:build " + src)
+        if err != nil { t.Fatal(err) }
+        if got := secretOnly(expr); got != want { t.Errorf("secretOnly(%s) = %t, want %t", src, got, want) }
+    }
+}
@@ -0,0 +1,257 @@
+// Command diffrun builds the driver twice from one source tree, once against
+// the memo (main_memo, built with -tags memo) and once against a student's
+// linked_list.go dropped into student/ (main_student), runs every task with
+// both and prints one line per section saying whether the outputs agree.
+// Tutors no longer have to copy files around by hand to compare the two.
+//
+// Run it from the starter root:
+//
+//	go run ./tools/diffrun [-student student] [-out bin] [-alt tag] [task...]
+//
+// -alt altslice or -alt altnotail builds the reference from memo/alt's
+// LinkedList on one of memo/altimpl's lists instead of the memo's
+// linked_list.go, which gives a second correct transcript of every task to
+// check a disputed section against.
+//
+// With no task arguments every registered task is run. The exit status is 1
+// when any section differs and 2 when a build or the memo run fails.
+package main
+
+import (
+    "bytes"
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+)
+
+const delim = "### " // v1 section header written by the driver
+
+func main() {
+    mainDir := flag.String("main", "main", "directory holding the driver sources")
+    memoDir := flag.String("memo", "memo", "directory holding the memo linked_list.go")
+    studentDir := flag.String("student", "student", "directory holding the student's linked_list.go")
+    outDir := flag.String("out", "bin", "directory the main_memo and main_student binaries are written to")
+    alt := flag.String("alt", "", "build tag of a memo/alt implementation to use as the reference (altslice, altnotail)")
+    flag.Parse()
+
+    lines, differ, err := diffrun(*mainDir, *memoDir, *studentDir, *outDir, *alt, flag.Args())
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "diffrun:", err)
+        os.Exit(2)
+    }
+    for _, l := range lines { fmt.Println(l) }
+    fmt.Printf("diffrun: %d of %d sections differ\n", differ, len(lines))
+    if differ > 0 { os.Exit(1) }
+}
+
+// diffrun builds both binaries into outDir, runs the given tasks (all of
+// them when none are given) and returns the per-section report along with
+// how many of its lines are differences. A non-empty alt is added to the
+// memo build's tags and memo/alt's sources replace the memo's
+// linked_list.go, with memo/altimpl importable.
+func diffrun(mainDir, memoDir, studentDir, outDir, alt string, taskNames []string) ([]string, int, error) {
+    if err := os.MkdirAll(outDir, 0o755); err != nil { return nil, 0, err }
+    memoBin, studentBin := filepath.Join(outDir, "main_memo"), filepath.Join(outDir, "main_student")
+    memo := stage{tags: "memo", impl: []string{filepath.Join(memoDir, "linked_list.go")}}
+    if alt != "" {
+        memo.tags += "," + alt
+        var err error
+        if memo.impl, err = filepath.Glob(filepath.Join(memoDir, "alt", "*.go")); err != nil { return nil, 0, err }
+        if len(memo.impl) == 0 { return nil, 0, fmt.Errorf("-alt %s: no sources in %s", alt, filepath.Join(memoDir, "alt")) }
+        module, err := modulePath(memoDir)
+        if err != nil { return nil, 0, fmt.Errorf("-alt %s: %w", alt, err) }
+        memo.pkgs = map[string]string{module + "/altimpl": filepath.Join(memoDir, "altimpl")}
+    }
+    if err := build(mainDir, memoBin, memo); err != nil { return nil, 0, fmt.Errorf("memo: %w", err) }
+    if err := build(mainDir, studentBin, stage{impl: []string{filepath.Join(studentDir, "linked_list.go")}}); err != nil { return nil, 0, fmt.Errorf("student: %w", err) }
+
+    if len(taskNames) == 0 {
+        var err error
+        if taskNames, err = listTasks(memoBin); err != nil { return nil, 0, err }
+    }
+    var lines []string
+    differ := 0
+    for _, task := range taskNames {
+        want, _, err := run(memoBin, task)
+        if err != nil { return nil, 0, fmt.Errorf("memo %s: %w", task, err) }
+        got, crash, _ := run(studentBin, task)
+        for _, r := range compare(want, got, crash) {
+            if r.status != "ok" { differ++ }
+            lines = append(lines, fmt.Sprintf("%-28s %s", r.name, r.status))
+        }
+    }
+    return lines, differ, nil
+}
+
+// stage is what a build puts next to the driver: the list implementation's
+// sources (the grader's layout has linked_list.go), build tags and packages,
+// by import path, made importable through a GOPATH in the staging directory.
+type stage struct {
+    impl []string
+    tags string
+    pkgs map[string]string
+}
+
+// modulePath reads the module path from dir/go.mod.
+func modulePath(dir string) (string, error) {
+    mod, err := os.ReadFile(filepath.Join(dir, "go.mod"))
+    if err != nil { return "", err }
+    for _, line := range strings.Split(string(mod), "\n") {
+        if path, ok := strings.CutPrefix(strings.TrimSpace(line), "module "); ok { return strings.TrimSpace(path), nil }
+    }
+    return "", fmt.Errorf("%s has no module line", filepath.Join(dir, "go.mod"))
+}
+
+// build stages the driver's non-test sources next to st's implementation
+// and builds bin from them.
+func build(mainDir, bin string, st stage) error {
+    for _, src := range st.impl {
+        if _, err := os.Stat(src); err != nil { return err }
+    }
+    srcs, err := filepath.Glob(filepath.Join(mainDir, "*.go"))
+    if err != nil { return err }
+
+    dir, err := os.MkdirTemp("", "diffrun")
+    if err != nil { return err }
+    defer os.RemoveAll(dir)
+    for _, src := range append(srcs, st.impl...) {
+        if strings.HasSuffix(src, "_test.go") { continue }
+        abs, err := filepath.Abs(src)
+        if err != nil { return err }
+        if err := os.Symlink(abs, filepath.Join(dir, filepath.Base(src))); err != nil { return err }
+    }
+    env := append(os.Environ(), "GO111MODULE=off")
+    if len(st.pkgs) > 0 {
+        gopath := filepath.Join(dir, "gopath")
+        for path, pkgDir := range st.pkgs {
+            abs, err := filepath.Abs(pkgDir)
+            if err != nil { return err }
+            link := filepath.Join(gopath, "src", filepath.FromSlash(path))
+            if err := os.MkdirAll(filepath.Dir(link), 0o755); err != nil { return err }
+            if err := os.Symlink(abs, link); err != nil { return err }
+        }
+        env = append(env, "GOPATH="+gopath)
+    }
+
+    abs, err := filepath.Abs(bin)
+    if err != nil { return err }
+    cmd := exec.Command("go", "build", "-tags", st.tags, "-o", abs, ".")
+    cmd.Dir = dir
+    cmd.Env = env
+    if msg, err := cmd.CombinedOutput(); err != nil { return fmt.Errorf("go build: %v\n%s", err, msg) }
+    return nil
+}
+
+// listTasks asks the driver for its registered task names.
+func listTasks(bin string) ([]string, error) {
+    stdout, err := exec.Command(bin, "-list-tasks").Output()
+    if err != nil { return nil, fmt.Errorf("%s -list-tasks: %w", bin, err) }
+    var names []string
+    for _, line := range strings.Split(strings.TrimSpace(string(stdout)), "\n") {
+        if fields := strings.Fields(line); len(fields) > 0 { names = append(names, fields[0]) }
+    }
+    return names, nil
+}
+
+// section is one block of driver output: the header name and the lines
+// printed under it.
+type section struct {
+    name  string
+    lines []string
+}
+
+// run executes one task and splits its output into sections. crash holds the
+// first line of stderr when the driver exited abnormally (a student's panic;
+// unimplemented stubs are reported in the output instead); err is set in that
+// case too.
+func run(bin, task string) (sections []section, crash string, err error) {
+    var stdout, stderr bytes.Buffer
+    cmd := exec.Command(bin, task)
+    cmd.Stdout, cmd.Stderr = &stdout, &stderr
+    if err = cmd.Run(); err != nil {
+        var exit *exec.ExitError
+        if !errors.As(err, &exit) { return nil, "", err }
+        crash, _, _ = strings.Cut(strings.TrimSpace(stderr.String()), "\n")
+        if crash == "" { crash = err.Error() }
+        err = fmt.Errorf("%v: %s", err, crash)
+    }
+    return parseSections(stdout.String()), crash, err
+}
+
+func parseSections(stdout string) []section {
+    var sections []section
+    for _, line := range strings.Split(strings.TrimSuffix(stdout, "\n"), "\n") {
+        if name, ok := strings.CutPrefix(line, delim); ok {
+            sections = append(sections, section{name: name})
+        } else if len(sections) > 0 {
+            sections[len(sections)-1].lines = append(sections[len(sections)-1].lines, line)
+        }
+    }
+    return sections
+}
+
+// result is one report line: a section name and "ok" or why it differs.
+type result struct{ name, status string }
+
+// compare matches the student's sections to the memo's in order. When the
+// student run crashed, the last section it started is reported as the one
+// that crashed and the memo sections after it as not reached. A section the
+// driver ended with a NOT IMPLEMENTED line reports the missing method, and
+// one it emitted with only a NOT RUN line counts as not reached.
+func compare(want, got []section, crash string) []result {
+    byName := make(map[string]int, len(got))
+    for i, s := range got { byName[s.name] = i }
+    crashed := -1
+    if crash != "" && len(got) > 0 { crashed = len(got) - 1 }
+
+    var results []result
+    seen := make(map[string]bool, len(want))
+    for _, w := range want {
+        seen[w.name] = true
+        i, ok := byName[w.name]
+        switch {
+        case !ok && crash != "":
+            results = append(results, result{w.name, "not reached"})
+        case !ok:
+            results = append(results, result{w.name, "missing in student"})
+        case i == crashed:
+            results = append(results, result{w.name, "crashed: " + crash})
+        case stubbed(got[i].lines, notRun):
+            results = append(results, result{w.name, "not reached"})
+        case stubbed(got[i].lines, notImplemented):
+            name := strings.TrimPrefix(got[i].lines[len(got[i].lines)-1], notImplemented)
+            results = append(results, result{w.name, "not implemented: " + name})
+        default:
+            results = append(results, result{w.name, diffLines(w.lines, got[i].lines)})
+        }
+    }
+    for _, g := range got {
+        if !seen[g.name] { results = append(results, result{g.name, "extra in student"}) }
+    }
+    return results
+}
+
+// The lines the driver prints when a spec stub panics: notImplemented ends
+// the section that hit it, notRun is all the task's later sections hold.
+const (
+    notImplemented = "NOT IMPLEMENTED: "
+    notRun         = "NOT RUN: "
+)
+
+// stubbed reports whether a section's last line starts with prefix.
+func stubbed(lines []string, prefix string) bool {
+    return len(lines) > 0 && strings.HasPrefix(lines[len(lines)-1], prefix)
+}
+
+// diffLines returns "ok" or a description of the first differing line.
+func diffLines(want, got []string) string {
+    for i := 0; i < len(want) && i < len(got); i++ {
+        if want[i] != got[i] { return fmt.Sprintf("line %d: memo %q, student %q", i+1, want[i], got[i]) }
+    }
+    if len(want) != len(got) { return fmt.Sprintf("memo has %d lines, student %d", len(want), len(got)) }
+    return "ok"
+}
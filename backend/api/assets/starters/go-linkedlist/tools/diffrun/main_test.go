@@ -0,0 +1,113 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// studentFromSpec writes a student submission that is the spec skeleton with
+// PushBack and ToSlice implemented and every other stub left panicking.
+func studentFromSpec(t *testing.T) string {
+    t.Helper()
+    src, err := os.ReadFile(filepath.Join("..", "..", "spec", "linked_list.go"))
+    if err != nil { t.Fatal(err) }
+    s := string(src)
+    for stub, impl := range map[string]string{
+        `func (l *LinkedList) PushBack(v int) { panic(notImplemented("PushBack")) }`: `func (l *LinkedList) PushBack(v int) {
+    n := &node{val: v}
+    if l.tail == nil { l.head, l.tail = n, n } else { l.tail.next = n; l.tail = n }
+    l.size++
+}`,
+        `func (l *LinkedList) ToSlice() []int { panic(notImplemented("ToSlice")) }`: `func (l *LinkedList) ToSlice() []int {
+    vs := make([]int, 0, l.size)
+    for n := l.head; n != nil; n = n.next { vs = append(vs, n.val) }
+    return vs
+}`,
+    } {
+        if !strings.Contains(s, stub) { t.Fatalf("spec no longer contains %q", stub) }
+        s = strings.Replace(s, stub, impl, 1)
+    }
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "linked_list.go"), []byte(s), 0o644); err != nil { t.Fatal(err) }
+    return dir
+}
+
+// TestDiffrunFlagsUnimplementedSections builds both variants and checks that
+// exactly the sections needing an unimplemented method are flagged: the one
+// that hits the first stub in each task names it and the rest of that task is
+// not reached, while sections using only PushBack and ToSlice agree.
+func TestDiffrunFlagsUnimplementedSections(t *testing.T) {
+    lines, differ, err := diffrun(filepath.Join("..", "..", "main"), filepath.Join("..", "..", "memo"), studentFromSpec(t), t.TempDir(), "", nil)
+    if err != nil { t.Fatal(err) }
+    for _, name := range []string{"Task1Start", "Task1EmptyList", "Task2Start", "Task3Start", "Task4Start", "Task5Start"} {
+        if !hasLine(lines, name, "ok") { t.Errorf("%s should match the memo:\n%s", name, strings.Join(lines, "\n")) }
+    }
+    for name, stub := range map[string]string{
+        "Task1PushFrontBack": "PushFront", "Task2Insert": "InsertAt", "Task3CopyCtor": "Copy",
+        "Task4CopyReversed": "CopyReversed", "Task5ReplaceAll": "ReplaceAll",
+    } {
+        if !hasLine(lines, name, "not implemented: "+stub) { t.Errorf("%s should stop at %s:\n%s", name, stub, strings.Join(lines, "\n")) }
+    }
+    for _, name := range []string{"Task1FrontBack", "Task2Erase", "Task3MoveAssignSim", "Task4Summary", "Task5ApplyAt"} {
+        if !hasLine(lines, name, "not reached") { t.Errorf("%s should not be reached:\n%s", name, strings.Join(lines, "\n")) }
+    }
+    if want := len(lines) - 6; differ != want { t.Errorf("%d sections differ, want %d", differ, want) }
+}
+
+func TestDiffrunMemoAgainstItself(t *testing.T) {
+    memo := filepath.Join("..", "..", "memo")
+    lines, differ, err := diffrun(filepath.Join("..", "..", "main"), memo, memo, t.TempDir(), "", []string{"task2"})
+    if err != nil { t.Fatal(err) }
+    if differ != 0 || len(lines) != 4 { t.Fatalf("memo vs memo on task2 (%d differ):\n%s", differ, strings.Join(lines, "\n")) }
+}
+
+// TestDiffrunAltAgainstMemo uses each memo/alt build as the reference with
+// the memo as the student: every section of every task must agree.
+func TestDiffrunAltAgainstMemo(t *testing.T) {
+    memo := filepath.Join("..", "..", "memo")
+    for _, alt := range []string{"altslice", "altnotail"} {
+        lines, differ, err := diffrun(filepath.Join("..", "..", "main"), memo, memo, t.TempDir(), alt, nil)
+        if err != nil { t.Fatalf("%s: %v", alt, err) }
+        if differ != 0 || len(lines) == 0 { t.Errorf("%s vs memo (%d differ):\n%s", alt, differ, strings.Join(lines, "\n")) }
+    }
+    if _, _, err := diffrun(filepath.Join("..", "..", "main"), t.TempDir(), memo, t.TempDir(), "altslice", nil); err == nil { t.Error("-alt without memo/alt sources was accepted") }
+}
+
+func hasLine(lines []string, name, status string) bool {
+    for _, l := range lines {
+        if f := strings.Fields(l); len(f) > 0 && f[0] == name { return strings.TrimSpace(strings.TrimPrefix(l, name)) == status }
+    }
+    return false
+}
+
+func TestCompare(t *testing.T) {
+    want := parseSections("### A\nx=1\n### B\ny=2\ny=3\n### C\n### D\nz\n")
+    cases := []struct {
+        name  string
+        got   string
+        crash string
+        want  []result
+    }{
+        {"equal", "### A\nx=1\n### B\ny=2\ny=3\n### C\n### D\nz\n", "", []result{{"A", "ok"}, {"B", "ok"}, {"C", "ok"}, {"D", "ok"}}},
+        {"line and length", "### A\nx=2\n### B\ny=2\n### C\n### D\nz\nextra\n", "", []result{
+            {"A", `line 1: memo "x=1", student "x=2"`}, {"B", "memo has 2 lines, student 1"}, {"C", "ok"}, {"D", "memo has 1 lines, student 2"},
+        }},
+        {"crash", "### A\nx=1\n### B\ny=2\n", "panic: TODO: Foo", []result{
+            {"A", "ok"}, {"B", "crashed: panic: TODO: Foo"}, {"C", "not reached"}, {"D", "not reached"},
+        }},
+        {"not implemented", "### A\nx=1\n### B\ny=2\nNOT IMPLEMENTED: Foo\n### C\nNOT RUN: Foo is not implemented\n### D\nNOT RUN: Foo is not implemented\n", "", []result{
+            {"A", "ok"}, {"B", "not implemented: Foo"}, {"C", "not reached"}, {"D", "not reached"},
+        }},
+        {"missing and extra", "### A\nx=1\n### B\ny=2\ny=3\n### D\nz\n### E\n", "", []result{
+            {"A", "ok"}, {"B", "ok"}, {"C", "missing in student"}, {"D", "ok"}, {"E", "extra in student"},
+        }},
+    }
+    for _, c := range cases {
+        if got := compare(want, parseSections(c.got), c.crash); !reflect.DeepEqual(got, c.want) {
+            t.Errorf("%s: compare = %v, want %v", c.name, got, c.want)
+        }
+    }
+}
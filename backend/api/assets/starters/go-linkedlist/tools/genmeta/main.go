@@ -0,0 +1,188 @@
+// Command genmeta writes starter.json, a machine-readable description of this
+// starter for the API that serves it: the language, how to run it, every task
+// with the section names it emits, which files belong to main/, memo/, spec/
+// and makefile/, and the Go version from go.mod when there is one.
+//
+// Nothing is inferred from names: the driver is built against the memo and
+// asked for its tasks and sections with -list-tasks, and each task's make
+// target is checked against the Makefile. Run it from the starter root after
+// changing tasks, sections or files:
+//
+//	go run ./tools/genmeta [-root .] [-check]
+//
+// With -check nothing is written; it exits 1 when starter.json is missing or
+// differs from what would be generated.
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "flag"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// metaFile is the generated file's name in the starter root.
+const metaFile = "starter.json"
+
+// Meta is the content of starter.json.
+type Meta struct {
+    Language   string              `json:"language"`
+    Entrypoint string              `json:"entrypoint"`
+    Run        string              `json:"run"`
+    GoVersion  string              `json:"go_version,omitempty"`
+    Tasks      []Task              `json:"tasks"`
+    Files      map[string][]string `json:"files"`
+}
+
+// Task is one driver task: the name it runs under, the command that runs it
+// and the section names it emits, in order.
+type Task struct {
+    Name     string   `json:"name"`
+    Command  string   `json:"command"`
+    Sections []string `json:"sections"`
+}
+
+// fileDirs are the directories whose files starter.json lists.
+var fileDirs = []string{"main", "memo", "spec", "makefile"}
+
+func main() {
+    root := flag.String("root", ".", "starter root holding main/, memo/, spec/ and makefile/")
+    check := flag.Bool("check", false, "report a stale starter.json instead of writing it")
+    flag.Parse()
+
+    src, err := generate(*root)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "genmeta:", err)
+        os.Exit(2)
+    }
+    path := filepath.Join(*root, metaFile)
+    if *check {
+        if stale(path, src) {
+            fmt.Printf("%s is stale; run go run ./tools/genmeta\n", path)
+            os.Exit(1)
+        }
+        return
+    }
+    if err := os.WriteFile(path, src, 0o644); err != nil {
+        fmt.Fprintln(os.Stderr, "genmeta:", err)
+        os.Exit(2)
+    }
+}
+
+// stale reports whether the file at path is missing or differs from src.
+func stale(path string, src []byte) bool {
+    cur, err := os.ReadFile(path)
+    return err != nil || !bytes.Equal(cur, src)
+}
+
+// generate builds starter.json for the starter at root.
+func generate(root string) ([]byte, error) {
+    makefile, err := os.ReadFile(filepath.Join(root, "makefile", "Makefile"))
+    if err != nil { return nil, err }
+    targets := makeTargets(string(makefile))
+    if !targets["run"] { return nil, fmt.Errorf("the Makefile has no run target") }
+
+    tasks, err := driverTasks(root)
+    if err != nil { return nil, err }
+    for i := range tasks {
+        if !targets[tasks[i].Name] { return nil, fmt.Errorf("the Makefile has no target for task %s", tasks[i].Name) }
+        tasks[i].Command = "make " + tasks[i].Name
+    }
+
+    meta := Meta{Language: "go", Entrypoint: "main.go", Run: "make run", Tasks: tasks, Files: map[string][]string{}}
+    for _, dir := range fileDirs {
+        if meta.Files[dir], err = sourceFiles(filepath.Join(root, dir)); err != nil { return nil, err }
+    }
+    if meta.GoVersion, err = goVersion(root); err != nil { return nil, err }
+
+    var buf bytes.Buffer
+    enc := json.NewEncoder(&buf)
+    enc.SetIndent("", "\t")
+    if err := enc.Encode(meta); err != nil { return nil, err }
+    return buf.Bytes(), nil
+}
+
+var targetLine = regexp.MustCompile(`(?m)^([A-Za-z0-9_.-]+):`)
+
+// makeTargets returns the names of the rules defined in a Makefile.
+func makeTargets(makefile string) map[string]bool {
+    targets := map[string]bool{}
+    for _, m := range targetLine.FindAllStringSubmatch(makefile, -1) { targets[m[1]] = true }
+    return targets
+}
+
+// driverTasks builds the driver against the memo and returns its tasks and
+// sections as -list-tasks prints them, one task per line.
+func driverTasks(root string) ([]Task, error) {
+    tmp, err := os.MkdirTemp("", "genmeta")
+    if err != nil { return nil, err }
+    defer os.RemoveAll(tmp)
+    bin := filepath.Join(tmp, "memo")
+    if err := build(filepath.Join(root, "main"), filepath.Join(root, "memo"), bin); err != nil { return nil, err }
+    stdout, err := exec.Command(bin, "-list-tasks").Output()
+    if err != nil { return nil, fmt.Errorf("driver -list-tasks: %w", err) }
+    var tasks []Task
+    for _, line := range strings.Split(strings.TrimSpace(string(stdout)), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) < 2 { return nil, fmt.Errorf("driver -list-tasks printed %q, want a task and its sections", line) }
+        tasks = append(tasks, Task{Name: fields[0], Sections: fields[1:]})
+    }
+    return tasks, nil
+}
+
+// build stages the driver's non-test sources next to implDir's
+// linked_list.go, the layout the grader compiles, and builds bin from them.
+func build(mainDir, implDir, bin string) error {
+    srcs, err := filepath.Glob(filepath.Join(mainDir, "*.go"))
+    if err != nil { return err }
+    stage, err := os.MkdirTemp("", "genmeta-build")
+    if err != nil { return err }
+    defer os.RemoveAll(stage)
+    for _, src := range append(srcs, filepath.Join(implDir, "linked_list.go")) {
+        if strings.HasSuffix(src, "_test.go") { continue }
+        abs, err := filepath.Abs(src)
+        if err != nil { return err }
+        if err := os.Symlink(abs, filepath.Join(stage, filepath.Base(src))); err != nil { return err }
+    }
+    cmd := exec.Command("go", "build", "-o", bin, ".")
+    cmd.Dir = stage
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { return fmt.Errorf("go build: %v\n%s", err, msg) }
+    return nil
+}
+
+// sourceFiles lists, in name order, the files directly in dir that ship with
+// the starter: everything but tests, subdirectories and the go.mod that
+// tools/modinit keeps in each variant directory.
+func sourceFiles(dir string) ([]string, error) {
+    entries, err := os.ReadDir(dir)
+    if err != nil { return nil, err }
+    files := []string{}
+    for _, e := range entries {
+        if !e.IsDir() && !strings.HasSuffix(e.Name(), "_test.go") && !moduleFiles[e.Name()] { files = append(files, e.Name()) }
+    }
+    sort.Strings(files)
+    return files, nil
+}
+
+// moduleFiles are module bookkeeping, not starter files.
+var moduleFiles = map[string]bool{"go.mod": true, "go.sum": true}
+
+var goDirective = regexp.MustCompile(`(?m)^go\s+(\S+)\s*$`)
+
+// goVersion returns the version in root/go.mod's go directive, or "" when
+// the starter has no go.mod.
+func goVersion(root string) (string, error) {
+    mod, err := os.ReadFile(filepath.Join(root, "go.mod"))
+    if os.IsNotExist(err) { return "", nil }
+    if err != nil { return "", err }
+    m := goDirective.FindSubmatch(mod)
+    if m == nil { return "", fmt.Errorf("go.mod has no go directive") }
+    return string(m[1]), nil
+}
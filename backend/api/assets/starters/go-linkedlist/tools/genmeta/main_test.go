@@ -0,0 +1,132 @@
+package main
+
+import (
+    "bytes"
+    "encoding/json"
+    "os"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+var starterRoot = filepath.Join("..", "..")
+
+// TestStarterJSONUpToDate is the golden test: the committed starter.json
+// must be exactly what genmeta generates (go run ./tools/genmeta rewrites it).
+func TestStarterJSONUpToDate(t *testing.T) {
+    src, err := generate(starterRoot)
+    if err != nil { t.Fatal(err) }
+    if stale(filepath.Join(starterRoot, metaFile), src) { t.Fatalf("%s is stale (run go run ./tools/genmeta from the starter root)", metaFile) }
+}
+
+// TestSchema decodes starter.json strictly and checks every field: known
+// keys only, tasks with make commands and non-empty section lists named
+// after the task, and file lists that name files which exist.
+func TestSchema(t *testing.T) {
+    data, err := os.ReadFile(filepath.Join(starterRoot, metaFile))
+    if err != nil { t.Fatal(err) }
+    dec := json.NewDecoder(bytes.NewReader(data))
+    dec.DisallowUnknownFields()
+    var meta Meta
+    if err := dec.Decode(&meta); err != nil { t.Fatalf("%s: %v", metaFile, err) }
+
+    if meta.Language != "go" || meta.Entrypoint != "main.go" || meta.Run != "make run" {
+        t.Errorf("language %q, entrypoint %q, run %q", meta.Language, meta.Entrypoint, meta.Run)
+    }
+    if len(meta.Tasks) == 0 { t.Fatal("no tasks") }
+    for i, task := range meta.Tasks {
+        if task.Command != "make "+task.Name { t.Errorf("task %d: command %q for %q", i, task.Command, task.Name) }
+        if len(task.Sections) == 0 { t.Errorf("%s: no sections", task.Name) }
+        prefix := "Task" + strings.TrimPrefix(task.Name, "task")
+        for _, sec := range task.Sections {
+            if !strings.HasPrefix(sec, prefix) { t.Errorf("%s: section %q does not start with %q", task.Name, sec, prefix) }
+        }
+    }
+    var dirs []string
+    for dir, files := range meta.Files {
+        dirs = append(dirs, dir)
+        if len(files) == 0 { t.Errorf("files.%s is empty", dir) }
+        for _, f := range files {
+            if _, err := os.Stat(filepath.Join(starterRoot, dir, f)); err != nil { t.Errorf("files.%s: %v", dir, err) }
+        }
+    }
+    if len(dirs) != len(fileDirs) { t.Errorf("files has %v, want %v", dirs, fileDirs) }
+}
+
+func TestDeterministic(t *testing.T) {
+    first, err := generate(starterRoot)
+    if err != nil { t.Fatal(err) }
+    second, err := generate(starterRoot)
+    if err != nil { t.Fatal(err) }
+    if !bytes.Equal(first, second) { t.Fatalf("two runs differ:\n%s\n---\n%s", first, second) }
+}
+
+// copyStarter copies the driver, memo, spec and Makefile into a new root.
+func copyStarter(t *testing.T) string {
+    t.Helper()
+    root := t.TempDir()
+    for _, dir := range fileDirs {
+        files, err := sourceFiles(filepath.Join(starterRoot, dir))
+        if err != nil { t.Fatal(err) }
+        if err := os.MkdirAll(filepath.Join(root, dir), 0o755); err != nil { t.Fatal(err) }
+        for _, f := range files {
+            data, err := os.ReadFile(filepath.Join(starterRoot, dir, f))
+            if err != nil { t.Fatal(err) }
+            if err := os.WriteFile(filepath.Join(root, dir, f), data, 0o644); err != nil { t.Fatal(err) }
+        }
+    }
+    return root
+}
+
+// TestNewSectionMakesItStale registers one more task1 section in a copy of
+// the driver: the starter.json generated before must then be stale, and the
+// new one must list the section.
+func TestNewSectionMakesItStale(t *testing.T) {
+    root := copyStarter(t)
+    before, err := generate(root)
+    if err != nil { t.Fatal(err) }
+    path := filepath.Join(root, metaFile)
+    if err := os.WriteFile(path, before, 0o644); err != nil { t.Fatal(err) }
+
+    mainGo := filepath.Join(root, "main", "main.go")
+    src, err := os.ReadFile(mainGo)
+    if err != nil { t.Fatal(err) }
+    edited := strings.Replace(string(src), `"pop_last_then_push"}`, `"pop_last_then_push", "extra"}`, 1)
+    if edited == string(src) { t.Fatal("task1's registration not found in main.go") }
+    if err := os.WriteFile(mainGo, []byte(edited), 0o644); err != nil { t.Fatal(err) }
+
+    after, err := generate(root)
+    if err != nil { t.Fatal(err) }
+    if !stale(path, after) { t.Fatal("starter.json not stale after adding a section") }
+    var meta Meta
+    if err := json.Unmarshal(after, &meta); err != nil { t.Fatal(err) }
+    if got := meta.Tasks[0].Sections; got[len(got)-1] != "Task1Extra" { t.Fatalf("task1 sections %v, want Task1Extra last", got) }
+}
+
+func TestMakefileMustHaveTaskTargets(t *testing.T) {
+    root := copyStarter(t)
+    if err := os.WriteFile(filepath.Join(root, "makefile", "Makefile"), []byte("run:\ntask1:\n"), 0o644); err != nil { t.Fatal(err) }
+    if _, err := generate(root); err == nil || !strings.Contains(err.Error(), "no target for task task2") { t.Fatalf("generate err = %v, want a missing task2 target", err) }
+}
+
+func TestGoVersion(t *testing.T) {
+    root := t.TempDir()
+    if v, err := goVersion(root); v != "" || err != nil { t.Fatalf("without go.mod: (%q, %v)", v, err) }
+    cases := map[string]string{
+        "module example.com/x\n\ngo 1.22\n":                  "1.22",
+        "module example.com/x\n\ngo 1.21.3\n\nrequire (\n)\n": "1.21.3",
+    }
+    for mod, want := range cases {
+        if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(mod), 0o644); err != nil { t.Fatal(err) }
+        if v, err := goVersion(root); v != want || err != nil { t.Errorf("goVersion(%q) = (%q, %v), want %q", mod, v, err, want) }
+    }
+    if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte("module example.com/x\n"), 0o644); err != nil { t.Fatal(err) }
+    if _, err := goVersion(root); err == nil { t.Error("accepted a go.mod without a go directive") }
+}
+
+func TestMakeTargets(t *testing.T) {
+    got := makeTargets("GO := go\n\nbuild: $(BINARY)\n\ntask1: build\n\t./app task1\n\n.PHONY: build task1\n")
+    want := map[string]bool{"build": true, "task1": true, ".PHONY": true}
+    if !reflect.DeepEqual(got, want) { t.Fatalf("makeTargets = %v, want %v", got, want) }
+}
@@ -0,0 +1,552 @@
+// Command genspec generates the spec skeleton from the memo: every function
+// and method body becomes panic(notImplemented("<Name>")) unless the function
+// is kept for the chosen tier, signatures, receivers, doc comments and type, var and
+// const declarations are copied as they are, unexported helper functions are
+// dropped and imports nothing references any more are removed. The result is
+// run through go/format, with the indentation then widened to this starter's
+// four spaces, and linked_list.go ends with the notImplemented helper the
+// stubs in every file use. list_api.go, the memo's copy of the driver's
+// ListAPI, is copied as it is: every copy must match the driver's byte for
+// byte.
+//
+// Run it from the starter root after changing the memo's API:
+//
+//	go run ./tools/genspec [-memo memo] [-spec spec] [-tier standard] [-keep Name,...] [-check]
+//
+// With -check nothing is written; it exits 1 when a spec file differs from
+// what would be generated.
+//
+// Tiers give modules easier or harder variants of the same spec. The tiers
+// file (spec_tiers.json in the starter root) maps a function ("Name") or
+// method ("Type.Name") to the hardest tier in which students are given its
+// memo body: easy < standard < hard. -tier easy keeps every listed name,
+// -tier hard only those mapped to hard, and names not listed are stubbed in
+// every tier. The committed spec/ is the standard tier; generate the others
+// into a directory of their own with -spec. A kept body that uses a function
+// the tier stubs or drops is an error, as the variant would not work as given.
+//
+// With -split the spec's linked_list.go is spread over topic files instead,
+// while the memo stays one file. The split file (spec_split.json in the
+// starter root) maps a declaration of the memo's linked_list.go ("Name",
+// "Type.Name", or a type, var or const name) to the spec file it goes in;
+// everything it does not list, and the notImplemented helper, goes in
+// core.go. Write a split into a directory of its own, and list its files in
+// the Makefile's SOURCES before shipping it:
+//
+//	go run ./tools/genspec -split spec_split.json -spec spec-split
+package main
+
+import (
+    "bytes"
+    "flag"
+    "fmt"
+    "go/ast"
+    "go/build"
+    "encoding/json"
+    "go/format"
+    "go/importer"
+    "go/parser"
+    "go/token"
+    "go/types"
+    "os"
+    "path"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strconv"
+    "strings"
+)
+
+// tierRank orders the tiers from easiest to hardest.
+var tierRank = map[string]int{"easy": 0, "standard": 1, "hard": 2}
+
+// helpersFile is the generated file helpers is appended to: the one the
+// grader compiles, and the package's other files share it.
+const helpersFile = "linked_list.go"
+
+// helpers is appended to helpersFile. The stubs panic with
+// notImplemented's value rather than call a helper that panics, because a
+// call is not a terminating statement and stubs with results would not compile.
+const helpers = `
+// notImplemented is the value the stubs panic with. The driver prints it as
+// a NOT IMPLEMENTED line in the section that called the stub, and marks the
+// task's remaining sections as not run instead of crashing with a stack
+// trace. Replace a stub's panic with your implementation.
+func notImplemented(name string) todoError { return todoError{name} }
+
+// todoError names the function or method that is not implemented yet.
+type todoError struct{ name string }
+
+func (e todoError) Error() string          { return "TODO: " + e.name }
+func (e todoError) NotImplemented() string { return e.name }
+`
+
+// apiFile is the memo's copy of the driver's interface file, which the spec
+// gets unchanged.
+const apiFile = "list_api.go"
+
+// coreFile receives the declarations of helpersFile that a split does not
+// map elsewhere, and the helpers.
+const coreFile = "core.go"
+
+// header opens every generated file; it is not a package doc comment.
+const header = "// Spec skeleton (students implement these methods), generated from the\n// memo by tools/genspec.\n\n"
+
+func main() {
+    memoDir := flag.String("memo", "memo", "directory holding the memo implementation")
+    specDir := flag.String("spec", "spec", "directory the skeleton is written to")
+    tiersFile := flag.String("tiers", "spec_tiers.json", "file mapping kept functions to the hardest tier that keeps them")
+    tier := flag.String("tier", "standard", "difficulty tier to generate: easy, standard or hard")
+    keepList := flag.String("keep", "", "comma-separated functions to keep as well as the tier's (Name or Type.Method)")
+    splitFile := flag.String("split", "", "file mapping declarations of linked_list.go to topic files (default: no split)")
+    check := flag.Bool("check", false, "report stale spec files instead of writing them")
+    flag.Parse()
+
+    tiers, err := loadTiers(*tiersFile)
+    var keep map[string]bool
+    if err == nil { keep, err = tierKeep(tiers, *tier) }
+    var split map[string]string
+    if err == nil && *splitFile != "" { split, err = loadSplit(*splitFile) }
+    var files map[string][]byte
+    if err == nil {
+        for name := range keepSet(*keepList) { keep[name] = true }
+        files, err = generate(*memoDir, keep, split)
+    }
+    if err == nil && split != nil && !*check {
+        if _, serr := os.Stat(filepath.Join(*specDir, helpersFile)); serr == nil {
+            err = fmt.Errorf("%s would be left next to the split files; write the split into a directory of its own", filepath.Join(*specDir, helpersFile))
+        }
+    }
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "genspec:", err)
+        os.Exit(2)
+    }
+    if *check {
+        stale := staleFiles(*specDir, files)
+        for _, out := range stale { fmt.Printf("%s is stale; run go run ./tools/genspec\n", out) }
+        if len(stale) > 0 { os.Exit(1) }
+        return
+    }
+    for name, src := range files {
+        if err := os.WriteFile(filepath.Join(*specDir, name), src, 0o644); err != nil {
+            fmt.Fprintln(os.Stderr, "genspec:", err)
+            os.Exit(2)
+        }
+    }
+}
+
+// staleFiles lists, in name order, the spec files that are missing or differ
+// from the generated ones.
+func staleFiles(specDir string, files map[string][]byte) []string {
+    var stale []string
+    for name, src := range files {
+        out := filepath.Join(specDir, name)
+        if cur, err := os.ReadFile(out); err != nil || !bytes.Equal(cur, src) { stale = append(stale, out) }
+    }
+    sort.Strings(stale)
+    return stale
+}
+
+// loadTiers reads a tiers file: a JSON object from function names to tiers.
+func loadTiers(path string) (map[string]string, error) {
+    data, err := os.ReadFile(path)
+    if err != nil { return nil, err }
+    var tiers map[string]string
+    if err := json.Unmarshal(data, &tiers); err != nil { return nil, fmt.Errorf("%s: %w", path, err) }
+    for name, tier := range tiers {
+        if _, ok := tierRank[tier]; !ok { return nil, fmt.Errorf("%s: %s has unknown tier %q", path, name, tier) }
+    }
+    return tiers, nil
+}
+
+// loadSplit reads a split file: a JSON object from declaration names to the
+// spec files they go in.
+func loadSplit(path string) (map[string]string, error) {
+    data, err := os.ReadFile(path)
+    if err != nil { return nil, err }
+    var split map[string]string
+    if err := json.Unmarshal(data, &split); err != nil { return nil, fmt.Errorf("%s: %w", path, err) }
+    for name, file := range split {
+        if !strings.HasSuffix(file, ".go") || strings.HasSuffix(file, "_test.go") || file != filepath.Base(file) || file == helpersFile {
+            return nil, fmt.Errorf("%s: %s goes in %q, want a file name ending in .go other than %s", path, name, file, helpersFile)
+        }
+    }
+    return split, nil
+}
+
+// tierKeep returns the names whose bodies tier keeps: those mapped to tier
+// or a harder one.
+func tierKeep(tiers map[string]string, tier string) (map[string]bool, error) {
+    rank, ok := tierRank[tier]
+    if !ok { return nil, fmt.Errorf("unknown tier %q (want easy, standard or hard)", tier) }
+    keep := map[string]bool{}
+    for name, t := range tiers {
+        if tierRank[t] >= rank { keep[name] = true }
+    }
+    return keep, nil
+}
+
+func keepSet(list string) map[string]bool {
+    keep := map[string]bool{}
+    for _, name := range strings.Split(list, ",") {
+        if name = strings.TrimSpace(name); name != "" { keep[name] = true }
+    }
+    return keep
+}
+
+// generate builds the skeleton of every non-test Go file in memoDir but
+// apiFile, which is copied, keyed by file name. A non-nil split spreads
+// helpersFile's skeleton over the files it names and coreFile.
+func generate(memoDir string, keep map[string]bool, split map[string]string) (map[string][]byte, error) {
+    pkg, err := build.ImportDir(memoDir, 0)
+    if err != nil { return nil, err }
+    fset := token.NewFileSet()
+    files := make([]*ast.File, len(pkg.GoFiles))
+    for i, name := range pkg.GoFiles {
+        if files[i], err = parser.ParseFile(fset, filepath.Join(memoDir, name), nil, parser.ParseComments); err != nil { return nil, err }
+    }
+    if err := checkKept(fset, files, keep); err != nil { return nil, err }
+    out := map[string][]byte{}
+    for i, name := range pkg.GoFiles {
+        if name == apiFile {
+            if out[name], err = os.ReadFile(filepath.Join(memoDir, name)); err != nil { return nil, err }
+            continue
+        }
+        if split != nil && name == helpersFile {
+            parts, err := splitSkeleton(fset, files[i], keep, split)
+            if err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+            for part, src := range parts {
+                if _, dup := out[part]; dup || contains(pkg.GoFiles, part) { return nil, fmt.Errorf("split file %s is also a memo file", part) }
+                out[part] = src
+            }
+            continue
+        }
+        src, err := skeleton(fset, files[i], keep, name == helpersFile)
+        if err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+        out[name] = src
+    }
+    return out, nil
+}
+
+func contains(names []string, name string) bool {
+    for _, n := range names {
+        if n == name { return true }
+    }
+    return false
+}
+
+// checkKept type-checks the memo and reports every kept body that uses a
+// function or method of the package the skeleton will not keep: exported
+// ones become stubs and unexported ones are dropped, so the spec would
+// either panic where the student was given working code or not compile.
+func checkKept(fset *token.FileSet, files []*ast.File, keep map[string]bool) error {
+    info := &types.Info{Uses: map[*ast.Ident]types.Object{}}
+    conf := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+    pkg, err := conf.Check("memo", fset, files, info)
+    if err != nil { return fmt.Errorf("memo does not type-check: %w", err) }
+    seen := map[string]bool{}
+    var problems []string
+    for _, f := range files {
+        for _, d := range f.Decls {
+            fn, ok := d.(*ast.FuncDecl)
+            if !ok || fn.Body == nil || !keep[funcName(fn)] { continue }
+            ast.Inspect(fn.Body, func(n ast.Node) bool {
+                id, ok := n.(*ast.Ident)
+                if !ok { return true }
+                used, ok := info.Uses[id].(*types.Func)
+                if !ok || used.Pkg() != pkg { return true }
+                name := objName(used)
+                if name == "" || keep[name] { return true }
+                what := "drops"
+                if exported(name) { what = "stubs" }
+                if msg := fmt.Sprintf("%s keeps its body but uses %s, which the tier %s", funcName(fn), name, what); !seen[msg] {
+                    seen[msg] = true
+                    problems = append(problems, msg)
+                }
+                return true
+            })
+        }
+    }
+    if len(problems) > 0 { return fmt.Errorf("%s", strings.Join(problems, "; ")) }
+    return nil
+}
+
+// objName is funcName for a type-checked function, or "" for an interface
+// method, which has no body to stub.
+func objName(fn *types.Func) string {
+    recv := fn.Type().(*types.Signature).Recv()
+    if recv == nil { return fn.Name() }
+    t := recv.Type()
+    if ptr, ok := t.(*types.Pointer); ok { t = ptr.Elem() }
+    named, ok := t.(*types.Named)
+    if !ok { return "" }
+    if _, iface := named.Underlying().(*types.Interface); iface { return "" }
+    return named.Obj().Name() + "." + fn.Name()
+}
+
+// skeleton rewrites f in place and returns the formatted result, ending
+// with helpers when withHelpers is set.
+func skeleton(fset *token.FileSet, f *ast.File, keep map[string]bool, withHelpers bool) ([]byte, error) {
+    if err := stubDecls(fset, f, keep); err != nil { return nil, err }
+    f.Decls = pruneImports(f.Decls)
+    return render(fset, f, withHelpers)
+}
+
+// splitSkeleton rewrites f in place and returns its declarations spread over
+// the files split names, in f's order, with the rest and the helpers in
+// coreFile. Each file gets the imports its declarations use and the
+// comments inside them; comments between declarations go in coreFile.
+func splitSkeleton(fset *token.FileSet, f *ast.File, keep map[string]bool, split map[string]string) (map[string][]byte, error) {
+    declared := map[string]bool{}
+    for _, d := range f.Decls { declared[declName(d)] = true }
+    var missing []string
+    for name := range split {
+        if !declared[name] { missing = append(missing, name) }
+    }
+    if len(missing) > 0 {
+        sort.Strings(missing)
+        return nil, fmt.Errorf("the split maps %s, which the memo does not declare", strings.Join(missing, ", "))
+    }
+    if err := stubDecls(fset, f, keep); err != nil { return nil, err }
+
+    var imports []*ast.GenDecl
+    parts := map[string]*ast.File{coreFile: nil}
+    for _, file := range split { parts[file] = nil }
+    for file := range parts { parts[file] = &ast.File{Package: f.Package, Name: f.Name} }
+    for _, d := range f.Decls {
+        if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT { imports = append(imports, gen); continue }
+        file := split[declName(d)]
+        if file == "" { file = coreFile }
+        parts[file].Decls = append(parts[file].Decls, d)
+    }
+    for _, c := range f.Comments {
+        file := coreFile
+        for name, part := range parts {
+            if within(c, declRanges(part.Decls)) { file = name; break }
+        }
+        parts[file].Comments = append(parts[file].Comments, c)
+    }
+
+    out := map[string][]byte{}
+    for name, part := range parts {
+        var decls []ast.Decl
+        for _, gen := range imports { decls = append(decls, copyImports(gen)) }
+        part.Decls = pruneImports(append(decls, part.Decls...))
+        src, err := render(fset, part, name == coreFile)
+        if err != nil { return nil, fmt.Errorf("%s: %w", name, err) }
+        out[name] = src
+    }
+    return out, nil
+}
+
+// declName is the name a split file maps d by: funcName for functions and
+// the first declared name for type, var and const declarations.
+func declName(d ast.Decl) string {
+    switch d := d.(type) {
+    case *ast.FuncDecl:
+        return funcName(d)
+    case *ast.GenDecl:
+        if len(d.Specs) == 0 { return "" }
+        switch spec := d.Specs[0].(type) {
+        case *ast.TypeSpec: return spec.Name.Name
+        case *ast.ValueSpec: return spec.Names[0].Name
+        }
+    }
+    return ""
+}
+
+// declRanges is the source range of each of decls, doc comment included.
+func declRanges(decls []ast.Decl) []ast.Node {
+    var ranges []ast.Node
+    for _, d := range decls {
+        ranges = append(ranges, d)
+        switch d := d.(type) {
+        case *ast.FuncDecl: if d.Doc != nil { ranges = append(ranges, d.Doc) }
+        case *ast.GenDecl: if d.Doc != nil { ranges = append(ranges, d.Doc) }
+        }
+    }
+    return ranges
+}
+
+// copyImports copies an import declaration deeply enough for pruneImports
+// to trim and move the copy without touching gen.
+func copyImports(gen *ast.GenDecl) *ast.GenDecl {
+    c := *gen
+    c.Specs = make([]ast.Spec, len(gen.Specs))
+    for i, s := range gen.Specs {
+        spec := *s.(*ast.ImportSpec)
+        path := *spec.Path
+        spec.Path = &path
+        if spec.Name != nil { name := *spec.Name; spec.Name = &name }
+        c.Specs[i] = &spec
+    }
+    return &c
+}
+
+// stubDecls replaces the bodies of f's functions that keep does not list
+// with stubs, drops its unexported functions and the comments inside what
+// it removed, and normalizes the exported doc comments.
+func stubDecls(fset *token.FileSet, f *ast.File, keep map[string]bool) error {
+    var decls []ast.Decl
+    var cut []ast.Node // source ranges whose comments go with the code
+    for _, d := range f.Decls {
+        fn, ok := d.(*ast.FuncDecl)
+        if !ok {
+            if err := normalizeGenDoc(fset, d.(*ast.GenDecl)); err != nil { return err }
+            decls = append(decls, d)
+            continue
+        }
+        name := funcName(fn)
+        if exported(name) {
+            if err := normalizeDoc(fset, fn.Doc, fn.Name.Name); err != nil { return fmt.Errorf("%s: %w", name, err) }
+        }
+        switch {
+        case keep[name]:
+        case !fn.Name.IsExported():
+            cut = append(cut, fn)
+            if fn.Doc != nil { cut = append(cut, fn.Doc) }
+            continue
+        case fn.Body != nil:
+            cut = append(cut, fn.Body)
+            fn.Body = stub(fn.Body.Lbrace, name)
+        }
+        decls = append(decls, fn)
+    }
+    f.Decls = decls
+    var comments []*ast.CommentGroup
+    for _, c := range f.Comments {
+        if !within(c, cut) { comments = append(comments, c) }
+    }
+    f.Comments = comments
+    return nil
+}
+
+// render formats f, ending with helpers when withHelpers is set, and opens
+// it with the generated-file header.
+func render(fset *token.FileSet, f *ast.File, withHelpers bool) ([]byte, error) {
+    var buf bytes.Buffer
+    if err := format.Node(&buf, fset, f); err != nil { return nil, err }
+    if withHelpers { buf.WriteString(helpers) }
+    src, err := format.Source(buf.Bytes())
+    if err != nil { return nil, err }
+    return append([]byte(header), widenIndent(src)...), nil
+}
+
+// normalizeDoc puts an exported declaration's doc comment into the standard
+// form students read in the spec: line comments, one space after the
+// slashes, no trailing blanks, starting with the declared name. A missing
+// doc or one about a different name is an error to fix in the memo.
+func normalizeDoc(fset *token.FileSet, doc *ast.CommentGroup, name string) error {
+    if doc == nil || strings.TrimSpace(doc.Text()) == "" { return fmt.Errorf("no doc comment in the memo") }
+    if first := strings.Fields(doc.Text())[0]; first != name { return fmt.Errorf("doc comment starts with %q, want %q", first, name) }
+    var lines []string
+    for _, line := range strings.Split(strings.TrimSpace(doc.Text()), "\n") {
+        if line = strings.TrimRight(line, " \t"); line == "" { lines = append(lines, "//") } else { lines = append(lines, "// "+line) }
+    }
+    // One comment per line, each at the start of a line the original doc
+    // spanned so the printer keeps them together.
+    file, start := fset.File(doc.Pos()), fset.Position(doc.Pos()).Line
+    if start+len(lines)-1 > file.LineCount() { return fmt.Errorf("doc comment grew past the end of the file") }
+    doc.List = doc.List[:0]
+    for i, line := range lines { doc.List = append(doc.List, &ast.Comment{Slash: file.LineStart(start + i), Text: line}) }
+    return nil
+}
+
+// normalizeGenDoc applies normalizeDoc to a type, var or const declaration
+// of a single exported name. Grouped declarations are left as they are.
+func normalizeGenDoc(fset *token.FileSet, gen *ast.GenDecl) error {
+    if gen.Tok == token.IMPORT || len(gen.Specs) != 1 { return nil }
+    var id *ast.Ident
+    switch spec := gen.Specs[0].(type) {
+    case *ast.TypeSpec: id = spec.Name
+    case *ast.ValueSpec: id = spec.Names[0]
+    }
+    if id == nil || !id.IsExported() { return nil }
+    if err := normalizeDoc(fset, gen.Doc, id.Name); err != nil { return fmt.Errorf("%s: %w", id.Name, err) }
+    return nil
+}
+
+// exported reports whether a funcName names part of the API: an exported
+// function, or an exported method of an exported type.
+func exported(name string) bool {
+    for _, part := range strings.Split(name, ".") {
+        if !ast.IsExported(part) { return false }
+    }
+    return true
+}
+
+// funcName is the name the tiers file and -keep use: Name for functions and
+// Type.Name for methods.
+func funcName(fn *ast.FuncDecl) string {
+    if fn.Recv == nil || len(fn.Recv.List) == 0 { return fn.Name.Name }
+    t := fn.Recv.List[0].Type
+    if star, ok := t.(*ast.StarExpr); ok { t = star.X }
+    if recv, ok := t.(*ast.Ident); ok { return recv.Name + "." + fn.Name.Name }
+    return fn.Name.Name
+}
+
+// stub is a one-statement body placed entirely at pos, so go/format keeps it
+// on the signature's line when it fits and never moves a comment into it. LinkedList's own methods
+// panic with the bare method name, as students read them most.
+func stub(pos token.Pos, name string) *ast.BlockStmt {
+    name = strings.TrimPrefix(name, "LinkedList.")
+    ident := func(s string) *ast.Ident { return &ast.Ident{NamePos: pos, Name: s} }
+    lit := &ast.BasicLit{ValuePos: pos, Kind: token.STRING, Value: strconv.Quote(name)}
+    todo := &ast.CallExpr{Fun: ident("notImplemented"), Lparen: pos, Args: []ast.Expr{lit}, Rparen: pos}
+    call := &ast.CallExpr{Fun: ident("panic"), Lparen: pos, Args: []ast.Expr{todo}, Rparen: pos}
+    return &ast.BlockStmt{Lbrace: pos, List: []ast.Stmt{&ast.ExprStmt{X: call}}, Rbrace: pos}
+}
+
+func within(c *ast.CommentGroup, ranges []ast.Node) bool {
+    for _, r := range ranges {
+        if c.Pos() >= r.Pos() && c.End() <= r.End() { return true }
+    }
+    return false
+}
+
+// pruneImports drops the imports the remaining declarations no longer refer to.
+func pruneImports(decls []ast.Decl) []ast.Decl {
+    used := map[string]bool{}
+    for _, d := range decls {
+        if gen, ok := d.(*ast.GenDecl); ok && gen.Tok == token.IMPORT { continue }
+        ast.Inspect(d, func(n ast.Node) bool {
+            if sel, ok := n.(*ast.SelectorExpr); ok {
+                if id, ok := sel.X.(*ast.Ident); ok { used[id.Name] = true }
+            }
+            return true
+        })
+    }
+    var out []ast.Decl
+    for _, d := range decls {
+        gen, ok := d.(*ast.GenDecl)
+        if !ok || gen.Tok != token.IMPORT { out = append(out, d); continue }
+        var specs []ast.Spec
+        for _, s := range gen.Specs {
+            if used[importName(s.(*ast.ImportSpec))] { specs = append(specs, s) }
+        }
+        // Move the kept specs up to the first lines so the dropped ones leave no gaps.
+        for i, s := range specs {
+            spec, pos := s.(*ast.ImportSpec), gen.Specs[i].(*ast.ImportSpec).Path.ValuePos
+            if spec.Name != nil { spec.Name.NamePos = pos }
+            spec.Path.ValuePos = pos
+        }
+        if len(specs) == 0 { continue }
+        if len(specs) == 1 { gen.Lparen, gen.Rparen = token.NoPos, token.NoPos }
+        gen.Specs = specs
+        out = append(out, gen)
+    }
+    return out
+}
+
+func importName(s *ast.ImportSpec) string {
+    if s.Name != nil { return s.Name.Name }
+    p, _ := strconv.Unquote(s.Path.Value)
+    return path.Base(p)
+}
+
+var leadingTabs = regexp.MustCompile(`(?m)^\t+`)
+
+// widenIndent replaces go/format's indenting tabs with four spaces each.
+func widenIndent(src []byte) []byte {
+    return leadingTabs.ReplaceAllFunc(src, func(tabs []byte) []byte { return bytes.Repeat([]byte("    "), len(tabs)) })
+}
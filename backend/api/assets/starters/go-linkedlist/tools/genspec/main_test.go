@@ -0,0 +1,438 @@
+package main
+
+import (
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "go/ast"
+    "go/parser"
+    "go/token"
+    "reflect"
+    "sort"
+    "strings"
+    "testing"
+)
+
+// TestSpecUpToDate is the golden test: the committed spec must be exactly
+// what genspec generates from the memo (go run ./tools/genspec rewrites it).
+func TestSpecUpToDate(t *testing.T) {
+    files, err := generate(filepath.Join("..", "..", "memo"), tierKept(t, "standard"), nil)
+    if err != nil { t.Fatal(err) }
+    if len(files) == 0 { t.Fatal("no memo files") }
+    if stale := staleFiles(filepath.Join("..", "..", "spec"), files); len(stale) > 0 {
+        t.Fatalf("stale spec files (run go run ./tools/genspec from the starter root): %v", stale)
+    }
+}
+
+// tierKept returns the names the starter's tiers file keeps for tier.
+func tierKept(t *testing.T, tier string) map[string]bool {
+    t.Helper()
+    tiers, err := loadTiers(filepath.Join("..", "..", "spec_tiers.json"))
+    if err != nil { t.Fatal(err) }
+    keep, err := tierKeep(tiers, tier)
+    if err != nil { t.Fatal(err) }
+    return keep
+}
+
+// buildDriver builds the driver's non-test sources next to files, as the
+// grader lays them out, and returns the binary.
+func buildDriver(t *testing.T, drivers []string, files map[string][]byte) (string, error) {
+    t.Helper()
+    stage := t.TempDir()
+    for name, src := range files {
+        if err := os.WriteFile(filepath.Join(stage, name), src, 0o644); err != nil { t.Fatal(err) }
+    }
+    for _, src := range drivers {
+        if strings.HasSuffix(src, "_test.go") { continue }
+        abs, err := filepath.Abs(src)
+        if err != nil { t.Fatal(err) }
+        if err := os.Symlink(abs, filepath.Join(stage, filepath.Base(src))); err != nil { t.Fatal(err) }
+    }
+    bin := filepath.Join(stage, "app")
+    build := exec.Command("go", "build", "-o", bin, ".")
+    build.Dir = stage
+    build.Env = append(os.Environ(), "GO111MODULE=off")
+    if out, err := build.CombinedOutput(); err != nil { return "", fmt.Errorf("%v\n%s", err, out) }
+    return bin, nil
+}
+
+func writePkg(t *testing.T, src string) string {
+    t.Helper()
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "linked_list.go"), []byte(src), 0o644); err != nil { t.Fatal(err) }
+    return dir
+}
+
+func TestSkeleton(t *testing.T) {
+    memo := writePkg(t, `package main
+
+import (
+    "errors"
+    "strconv"
+    "sync"
+)
+
+// ErrEmpty is returned on an empty list.
+var ErrEmpty = errors.New("empty")
+
+// LinkedList is a list.
+type LinkedList struct {
+    mu   sync.Mutex
+    size int // kept up to date by every method
+}
+
+// New returns a list.
+func New() *LinkedList { return &LinkedList{} }
+
+// Len reports the size.
+func (l *LinkedList) Len() int {
+    // cached
+    return l.size
+}
+
+// String formats the size.
+func (l *LinkedList) String() string { return strconv.Itoa(helper(l)) }
+
+// helper is internal.
+func helper(l *LinkedList) int { return l.size }
+
+// Box wraps a list.
+type Box struct{ l LinkedList }
+
+// Len reports the boxed size.
+func (b *Box) Len() int { return b.l.Len() }
+`)
+    want := header + `package main
+
+import (
+    "errors"
+    "sync"
+)
+
+// ErrEmpty is returned on an empty list.
+var ErrEmpty = errors.New("empty")
+
+// LinkedList is a list.
+type LinkedList struct {
+    mu   sync.Mutex
+    size int // kept up to date by every method
+}
+
+// New returns a list.
+func New() *LinkedList { return &LinkedList{} }
+
+// Len reports the size.
+func (l *LinkedList) Len() int { panic(notImplemented("Len")) }
+
+// String formats the size.
+func (l *LinkedList) String() string { panic(notImplemented("String")) }
+
+// Box wraps a list.
+type Box struct{ l LinkedList }
+
+// Len reports the boxed size.
+func (b *Box) Len() int { panic(notImplemented("Box.Len")) }
+` + helpers
+    files, err := generate(memo, keepSet("New"), nil)
+    if err != nil { t.Fatal(err) }
+    if got := string(files["linked_list.go"]); got != want { t.Fatalf("generated:\n%s\nwant:\n%s", got, want) }
+
+    files, err = generate(memo, keepSet(" New, LinkedList.Len ,Box.Len"), nil)
+    if err != nil { t.Fatal(err) }
+    got := string(files["linked_list.go"])
+    for _, kept := range []string{"    // cached\n    return l.size\n", "func (b *Box) Len() int { return b.l.Len() }"} {
+        if !strings.Contains(got, kept) { t.Errorf("kept body %q missing from:\n%s", kept, got) }
+    }
+}
+
+func TestCheckReportsStaleFiles(t *testing.T) {
+    spec := t.TempDir()
+    files := map[string][]byte{"a.go": []byte("package main\n"), "b.go": []byte("package main\n"), "c.go": []byte("package main\n")}
+    os.WriteFile(filepath.Join(spec, "a.go"), files["a.go"], 0o644)
+    os.WriteFile(filepath.Join(spec, "b.go"), []byte("package main // edited\n"), 0o644)
+    want := []string{filepath.Join(spec, "b.go"), filepath.Join(spec, "c.go")}
+    if got := staleFiles(spec, files); !reflect.DeepEqual(got, want) { t.Fatalf("staleFiles = %v, want %v", got, want) }
+}
+
+func TestSkeletonNormalizesDocs(t *testing.T) {
+    memo := writePkg(t, `package main
+
+// LinkedList is a list.
+type LinkedList struct{ size int }
+
+//Len  reports the size.   
+//
+//Zero when empty.
+func (l *LinkedList) Len() int { return l.size }
+
+/* Front returns the first value.
+Zero when empty. */
+func (l *LinkedList) Front() int { return 0 }
+`)
+    want := header + `package main
+
+// LinkedList is a list.
+type LinkedList struct{ size int }
+
+// Len  reports the size.
+//
+// Zero when empty.
+func (l *LinkedList) Len() int { panic(notImplemented("Len")) }
+
+// Front returns the first value.
+// Zero when empty.
+func (l *LinkedList) Front() int { panic(notImplemented("Front")) }
+` + helpers
+    files, err := generate(memo, keepSet(""), nil)
+    if err != nil { t.Fatal(err) }
+    if got := string(files["linked_list.go"]); got != want { t.Fatalf("generated:\n%s\nwant:\n%s", got, want) }
+}
+
+func TestSkeletonRejectsBadDocs(t *testing.T) {
+    cases := []struct{ src, msg string }{
+        {"package main\n// L is a list.\ntype L struct{}\nfunc (l *L) Len() int { return 0 }\n", "L.Len: no doc comment"},
+        {"package main\n// Size reports the size.\nfunc Len() int { return 0 }\n", `Len: doc comment starts with "Size", want "Len"`},
+        {"package main\nvar ErrX = 1\n", "ErrX: no doc comment"},
+    }
+    for _, c := range cases {
+        if _, err := generate(writePkg(t, c.src), keepSet(""), nil); err == nil || !strings.Contains(err.Error(), c.msg) {
+            t.Errorf("generate(%q): err = %v, want it to contain %q", c.src, err, c.msg)
+        }
+    }
+}
+
+// docs maps each exported function, method ("Type.Name") and single-name
+// type, var or const declaration in file to its doc comment text.
+func docs(t *testing.T, file string) map[string]string {
+    t.Helper()
+    f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.ParseComments)
+    if err != nil { t.Fatal(err) }
+    out := map[string]string{}
+    for _, d := range f.Decls {
+        switch d := d.(type) {
+        case *ast.FuncDecl:
+            if exported(funcName(d)) { out[funcName(d)] = d.Doc.Text() }
+        case *ast.GenDecl:
+            if d.Tok == token.IMPORT || len(d.Specs) != 1 { continue }
+            switch spec := d.Specs[0].(type) {
+            case *ast.TypeSpec: if spec.Name.IsExported() { out[spec.Name.Name] = d.Doc.Text() }
+            case *ast.ValueSpec: if spec.Names[0].IsExported() { out[spec.Names[0].Name] = d.Doc.Text() }
+            }
+        }
+    }
+    return out
+}
+
+// TestSpecDocsMatchMemo checks that every exported name in the spec has a
+// doc comment that starts with the name and reads as the memo's does.
+func TestSpecDocsMatchMemo(t *testing.T) {
+    for _, file := range []string{"linked_list.go", "array_list.go"} {
+        memo := docs(t, filepath.Join("..", "..", "memo", file))
+        spec := docs(t, filepath.Join("..", "..", "spec", file))
+        if len(spec) == 0 { t.Fatalf("%s: no exported names in the spec", file) }
+        for name, doc := range spec {
+            short := name[strings.LastIndex(name, ".")+1:]
+            if !strings.HasPrefix(doc, short+" ") { t.Errorf("%s: spec doc %q does not start with %q", name, doc, short) }
+            if doc != memo[name] { t.Errorf("%s: spec doc %q, memo doc %q", name, doc, memo[name]) }
+        }
+    }
+}
+
+// TestHelpersOnlyInLinkedList checks that a memo with several files gets a
+// spec that compiles as one package: only linked_list.go defines the
+// helpers the other files' stubs call.
+func TestHelpersOnlyInLinkedList(t *testing.T) {
+    memo := writePkg(t, "package main\n// New returns 1.\nfunc New() int { return 1 }\n")
+    if err := os.WriteFile(filepath.Join(memo, "array_list.go"), []byte("package main\n// Other returns 2.\nfunc Other() int { return 2 }\n"), 0o644); err != nil { t.Fatal(err) }
+    files, err := generate(memo, keepSet(""), nil)
+    if err != nil { t.Fatal(err) }
+    if !strings.HasSuffix(string(files["linked_list.go"]), helpers) { t.Errorf("linked_list.go does not end with the helpers:\n%s", files["linked_list.go"]) }
+    if got := string(files["array_list.go"]); strings.Contains(got, "func notImplemented") || !strings.Contains(got, `panic(notImplemented("Other"))`) {
+        t.Errorf("array_list.go:\n%s\nwant the stub without the helpers", got)
+    }
+}
+
+// TestAPIFileCopied checks that list_api.go reaches the spec unchanged, with
+// no header, so it stays a byte-for-byte copy of the driver's.
+func TestAPIFileCopied(t *testing.T) {
+    memo := writePkg(t, "package main\n// New returns 1.\nfunc New() int { return 1 }\n")
+    api := "package main\n\n// ListAPI is the driver's.\ntype ListAPI interface{ Len() int }\n"
+    if err := os.WriteFile(filepath.Join(memo, apiFile), []byte(api), 0o644); err != nil { t.Fatal(err) }
+    files, err := generate(memo, keepSet(""), nil)
+    if err != nil { t.Fatal(err) }
+    if got := string(files[apiFile]); got != api { t.Fatalf("%s:\n%s\nwant:\n%s", apiFile, got, api) }
+}
+
+func TestTierKeep(t *testing.T) {
+    tiers := map[string]string{"New": "hard", "LinkedList.Len": "standard", "LinkedList.PushBack": "easy"}
+    cases := map[string][]string{
+        "easy":     {"LinkedList.Len", "LinkedList.PushBack", "New"},
+        "standard": {"LinkedList.Len", "New"},
+        "hard":     {"New"},
+    }
+    for tier, want := range cases {
+        keep, err := tierKeep(tiers, tier)
+        if err != nil { t.Fatal(err) }
+        var got []string
+        for name := range keep { got = append(got, name) }
+        sort.Strings(got)
+        if !reflect.DeepEqual(got, want) { t.Errorf("tier %s keeps %v, want %v", tier, got, want) }
+    }
+    if _, err := tierKeep(tiers, "medium"); err == nil { t.Error("tierKeep accepted tier medium") }
+
+    path := filepath.Join(t.TempDir(), "tiers.json")
+    if err := os.WriteFile(path, []byte(`{"New": "trivial"}`), 0o644); err != nil { t.Fatal(err) }
+    if _, err := loadTiers(path); err == nil || !strings.Contains(err.Error(), `New has unknown tier "trivial"`) { t.Errorf("loadTiers err = %v, want an unknown tier", err) }
+}
+
+// TestKeptBodiesMustNotUseStubs keeps InsertAt, which calls PushFront and
+// PushBack, and Sum, which calls an unexported helper genspec drops: both
+// are errors until what they use is kept too.
+func TestKeptBodiesMustNotUseStubs(t *testing.T) {
+    memo := writePkg(t, `package main
+
+// LinkedList is a list.
+type LinkedList struct{ size int }
+
+// PushFront adds v at the front.
+func (l *LinkedList) PushFront(v int) { l.size++ }
+
+// PushBack adds v at the back.
+func (l *LinkedList) PushBack(v int) { l.size++ }
+
+// InsertAt inserts v at idx.
+func (l *LinkedList) InsertAt(idx, v int) {
+    if idx == 0 { l.PushFront(v); return }
+    l.PushBack(v)
+}
+
+// Sum adds the values.
+func (l *LinkedList) Sum() int { return sum(l) }
+
+func sum(l *LinkedList) int { return l.size }
+`)
+    cases := []struct{ keep, msg string }{
+        {"LinkedList.InsertAt", "LinkedList.InsertAt keeps its body but uses LinkedList.PushFront, which the tier stubs; LinkedList.InsertAt keeps its body but uses LinkedList.PushBack, which the tier stubs"},
+        {"LinkedList.InsertAt,LinkedList.PushFront", "LinkedList.InsertAt keeps its body but uses LinkedList.PushBack, which the tier stubs"},
+        {"LinkedList.Sum", "LinkedList.Sum keeps its body but uses sum, which the tier drops"},
+        {"LinkedList.InsertAt,LinkedList.PushFront,LinkedList.PushBack,LinkedList.Sum,sum", ""},
+    }
+    for _, c := range cases {
+        _, err := generate(memo, keepSet(c.keep), nil)
+        if c.msg == "" && err != nil { t.Errorf("keep %s: %v", c.keep, err) }
+        if c.msg != "" && (err == nil || err.Error() != c.msg) { t.Errorf("keep %s: err = %v, want %q", c.keep, err, c.msg) }
+    }
+}
+
+// TestTiersBuildAgainstTheDriver generates every tier of the starter's spec,
+// builds the driver against each as the grader would and runs task1. Every
+// tier must build and exit cleanly; the easy tier gives away everything
+// task1 uses, so its transcript must match the memo's golden one.
+func TestTiersBuildAgainstTheDriver(t *testing.T) {
+    root := filepath.Join("..", "..")
+    golden, err := os.ReadFile(filepath.Join(root, "main", "testdata", "golden", "task1.txt"))
+    if err != nil { t.Fatal(err) }
+    drivers, err := filepath.Glob(filepath.Join(root, "main", "*.go"))
+    if err != nil { t.Fatal(err) }
+    for tier := range tierRank {
+        files, err := generate(filepath.Join(root, "memo"), tierKept(t, tier), nil)
+        if err != nil { t.Fatalf("%s: %v", tier, err) }
+        bin, err := buildDriver(t, drivers, map[string][]byte{"linked_list.go": files["linked_list.go"]})
+        if err != nil { t.Fatalf("%s: the driver does not build against the tier: %v", tier, err) }
+        out, err := exec.Command(bin, "task1").Output()
+        if err != nil { t.Fatalf("%s: task1: %v\n%s", tier, err, out) }
+        if tier == "easy" && string(out) != string(golden) { t.Errorf("easy tier task1:\n%s\nwant the memo's:\n%s", out, golden) }
+        if tier != "easy" && !strings.Contains(string(out), "NOT IMPLEMENTED") { t.Errorf("%s tier task1 ran without reaching a stub:\n%s", tier, out) }
+    }
+}
+
+// apiNames returns the exported declarations of the Go files in srcs, by
+// declName, with how many times each is declared. Every file must be in
+// package main.
+func apiNames(t *testing.T, srcs map[string][]byte) map[string]int {
+    t.Helper()
+    names := map[string]int{}
+    for file, src := range srcs {
+        f, err := parser.ParseFile(token.NewFileSet(), file, src, 0)
+        if err != nil { t.Fatal(err) }
+        if f.Name.Name != "main" { t.Errorf("%s is in package %s, want main", file, f.Name.Name) }
+        for _, d := range f.Decls {
+            if name := declName(d); name != "" && exported(name) { names[name]++ }
+        }
+    }
+    return names
+}
+
+// TestSplitCoversTheAPIOnce splits the starter's spec with spec_split.json
+// and checks that the topic files together declare every exported name of
+// the memo's linked_list.go exactly once, and that array_list.go is left
+// as it is.
+func TestSplitCoversTheAPIOnce(t *testing.T) {
+    root := filepath.Join("..", "..")
+    split, err := loadSplit(filepath.Join(root, "spec_split.json"))
+    if err != nil { t.Fatal(err) }
+    files, err := generate(filepath.Join(root, "memo"), tierKept(t, "standard"), split)
+    if err != nil { t.Fatal(err) }
+    whole, err := generate(filepath.Join(root, "memo"), tierKept(t, "standard"), nil)
+    if err != nil { t.Fatal(err) }
+    if _, ok := files["linked_list.go"]; ok { t.Fatal("the split still has linked_list.go") }
+    if string(files["array_list.go"]) != string(whole["array_list.go"]) { t.Error("the split changed array_list.go") }
+    if string(files[apiFile]) != string(whole[apiFile]) { t.Errorf("the split changed %s", apiFile) }
+    for _, part := range []string{"core.go", "index.go", "algorithms.go", "semantics.go"} {
+        if _, ok := files[part]; !ok { t.Errorf("the split has no %s", part) }
+    }
+
+    memo, err := os.ReadFile(filepath.Join(root, "memo", "linked_list.go"))
+    if err != nil { t.Fatal(err) }
+    want := apiNames(t, map[string][]byte{"linked_list.go": memo})
+    delete(files, "array_list.go")
+    delete(files, apiFile)
+    got := apiNames(t, files)
+    for name := range want {
+        if got[name] != 1 { t.Errorf("%s is declared %d times in the split", name, got[name]) }
+    }
+    for name := range got {
+        if want[name] == 0 { t.Errorf("the split declares %s, which the memo's linked_list.go does not", name) }
+    }
+    if !strings.HasSuffix(string(files["core.go"]), helpers) { t.Error("core.go does not end with the helpers") }
+}
+
+// TestSplitBuildsAgainstTheDriver builds the driver against the split spec
+// and runs task1, which must reach a stub rather than fail to compile.
+func TestSplitBuildsAgainstTheDriver(t *testing.T) {
+    root := filepath.Join("..", "..")
+    split, err := loadSplit(filepath.Join(root, "spec_split.json"))
+    if err != nil { t.Fatal(err) }
+    files, err := generate(filepath.Join(root, "memo"), tierKept(t, "standard"), split)
+    if err != nil { t.Fatal(err) }
+    delete(files, apiFile) // the driver's own copy
+    drivers, err := filepath.Glob(filepath.Join(root, "main", "*.go"))
+    if err != nil { t.Fatal(err) }
+    bin, err := buildDriver(t, drivers, files)
+    if err != nil { t.Fatalf("the driver does not build against the split spec: %v", err) }
+    out, err := exec.Command(bin, "task1").Output()
+    if err != nil { t.Fatalf("task1: %v\n%s", err, out) }
+    if !strings.Contains(string(out), "NOT IMPLEMENTED") { t.Errorf("task1 ran without reaching a stub:\n%s", out) }
+}
+
+func TestSplitErrors(t *testing.T) {
+    memo := writePkg(t, "package main\n// New returns 1.\nfunc New() int { return 1 }\n")
+    if err := os.WriteFile(filepath.Join(memo, "array_list.go"), []byte("package main\n// Other returns 2.\nfunc Other() int { return 2 }\n"), 0o644); err != nil { t.Fatal(err) }
+    cases := []struct {
+        split map[string]string
+        msg   string
+    }{
+        {map[string]string{"New": "index.go", "Gone": "index.go", "Lost": "algorithms.go"}, "the split maps Gone, Lost, which the memo does not declare"},
+        {map[string]string{"New": "array_list.go"}, "split file array_list.go is also a memo file"},
+    }
+    for _, c := range cases {
+        if _, err := generate(memo, keepSet(""), c.split); err == nil || !strings.Contains(err.Error(), c.msg) { t.Errorf("split %v: err = %v, want %q", c.split, err, c.msg) }
+    }
+
+    for _, bad := range []string{`{"New": "linked_list.go"}`, `{"New": "core_test.go"}`, `{"New": "sub/core.go"}`, `{"New": "core"}`} {
+        path := filepath.Join(t.TempDir(), "split.json")
+        if err := os.WriteFile(path, []byte(bad), 0o644); err != nil { t.Fatal(err) }
+        if _, err := loadSplit(path); err == nil { t.Errorf("loadSplit accepted %s", bad) }
+    }
+}
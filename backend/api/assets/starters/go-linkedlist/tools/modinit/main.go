@@ -0,0 +1,131 @@
+// Command modinit creates or updates a starter's go.mod files: one at the
+// root, whose module holds tools/, and one in each variant directory (main/,
+// memo/, spec/, submission/, secret/) that exists. None of the variants
+// builds on its own: the driver needs an implementation next to it, the
+// implementations have no func main and the grading-only tasks in secret/
+// need the driver. As modules of their own they stay out of the root's
+// `go build ./...`, and editors treat each as a separate package instead of
+// one package path declaring LinkedList twice. A variant's module path is
+// the root's with the directory appended.
+//
+// Run it from the starter root:
+//
+//	go run ./tools/modinit [-root .] [-module path] [-go 1.27] [-check]
+//
+// -module and -go default to what the root go.mod already says; a starter
+// without one needs -module, and GO111MODULE=off to run modinit at all. The
+// go directive defaults to the running toolchain's version. Other lines in
+// an existing go.mod are kept. -check writes nothing and exits 1 when a
+// go.mod is missing or differs from what would be written.
+//
+// Staged builds (the driver and one implementation in a flat directory, as
+// the grader and the Makefile compile them) have no go.mod and still build
+// with GO111MODULE=off.
+package main
+
+import (
+    "bytes"
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "runtime"
+    "strings"
+)
+
+// variantDirs are the directories that get a module of their own.
+var variantDirs = []string{"main", "memo", "spec", "submission", "secret"}
+
+var (
+    moduleDirective = regexp.MustCompile(`(?m)^module\s+(\S+)[ \t]*$`)
+    goDirective     = regexp.MustCompile(`(?m)^go\s+(\S+)[ \t]*$`)
+    validModule     = regexp.MustCompile(`^[A-Za-z0-9._~-]+(/[A-Za-z0-9._~-]+)*$`)
+    validGoVersion  = regexp.MustCompile(`^1\.[0-9]+(\.[0-9]+)?$`)
+)
+
+func main() {
+    root := flag.String("root", ".", "starter root holding the variant directories")
+    module := flag.String("module", "", "root module path (default: the one in root/go.mod)")
+    goVersion := flag.String("go", "", "Go version for the go directives (default: root/go.mod's, else the running toolchain's)")
+    check := flag.Bool("check", false, "report missing or stale go.mod files instead of writing them")
+    flag.Parse()
+
+    stale, err := modinit(*root, *module, *goVersion, !*check)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "modinit:", err)
+        os.Exit(2)
+    }
+    for _, path := range stale {
+        if *check { fmt.Printf("%s is stale; run go run ./tools/modinit\n", path) } else { fmt.Printf("modinit: wrote %s\n", path) }
+    }
+    if *check && len(stale) > 0 { os.Exit(1) }
+}
+
+// modinit works out the root module path and Go version, then brings the
+// root's and each existing variant's go.mod up to date, writing them only
+// when write is set. It returns the paths that were, or would be, written.
+func modinit(root, module, goVersion string, write bool) ([]string, error) {
+    rootMod := filepath.Join(root, "go.mod")
+    cur, err := os.ReadFile(rootMod)
+    if err != nil && !os.IsNotExist(err) { return nil, err }
+    if module == "" {
+        m := moduleDirective.FindSubmatch(cur)
+        if m == nil { return nil, errors.New("no root go.mod to take the module path from; pass -module") }
+        module = string(m[1])
+    }
+    if goVersion == "" {
+        if m := goDirective.FindSubmatch(cur); m != nil { goVersion = string(m[1]) } else { goVersion = toolchainVersion() }
+    }
+    if !validModule.MatchString(module) { return nil, fmt.Errorf("invalid module path %q", module) }
+    if !validGoVersion.MatchString(goVersion) { return nil, fmt.Errorf("invalid Go version %q (want a version such as 1.27)", goVersion) }
+
+    mods := map[string]string{rootMod: module}
+    paths := []string{rootMod}
+    for _, dir := range variantDirs {
+        if fi, err := os.Stat(filepath.Join(root, dir)); err != nil || !fi.IsDir() { continue }
+        path := filepath.Join(root, dir, "go.mod")
+        mods[path] = module + "/" + dir
+        paths = append(paths, path)
+    }
+
+    var stale []string
+    for _, path := range paths {
+        cur, err := os.ReadFile(path)
+        if err != nil && !os.IsNotExist(err) { return nil, err }
+        want := render(cur, mods[path], goVersion)
+        if err == nil && bytes.Equal(cur, want) { continue }
+        stale = append(stale, path)
+        if write {
+            if err := os.WriteFile(path, want, 0o644); err != nil { return nil, err }
+        }
+    }
+    return stale, nil
+}
+
+// render returns cur with its module and go directives set to module and
+// goVersion, adding either when it is missing; an empty cur gives the same
+// two-directive file newstarter writes.
+func render(cur []byte, module, goVersion string) []byte {
+    s := string(cur)
+    if s == "" { return []byte("module " + module + "\n\ngo " + goVersion + "\n") }
+    if moduleDirective.MatchString(s) {
+        s = moduleDirective.ReplaceAllLiteralString(s, "module "+module)
+    } else {
+        s = "module " + module + "\n\n" + s
+    }
+    if goDirective.MatchString(s) {
+        s = goDirective.ReplaceAllLiteralString(s, "go "+goVersion)
+    } else {
+        s = strings.TrimSuffix(s, "\n") + "\n\ngo " + goVersion + "\n"
+    }
+    return []byte(s)
+}
+
+// toolchainVersion is the running Go's language version, such as 1.27.
+func toolchainVersion() string {
+    v := strings.TrimPrefix(runtime.Version(), "go")
+    if parts := strings.SplitN(v, ".", 3); len(parts) >= 2 { return parts[0] + "." + parts[1] }
+    return v
+}
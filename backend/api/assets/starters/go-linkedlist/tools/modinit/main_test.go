@@ -0,0 +1,78 @@
+package main
+
+import (
+    "os"
+    "os/exec"
+    "path/filepath"
+    "reflect"
+    "strings"
+    "testing"
+)
+
+// TestStarterModulesUpToDate fails when this starter's go.mod files differ
+// from what modinit would write.
+func TestStarterModulesUpToDate(t *testing.T) {
+    stale, err := modinit(filepath.Join("..", ".."), "", "", false)
+    if err != nil { t.Fatal(err) }
+    if len(stale) > 0 { t.Errorf("%v are stale (run go run ./tools/modinit from the starter root)", stale) }
+}
+
+// cleanModuleEnv is the environment of a module-mode build with no GOPATH
+// workspace to fall back on, no go.work to widen it and no toolchain switch.
+func cleanModuleEnv(t *testing.T) []string {
+    return append(os.Environ(), "GO111MODULE=on", "GOPATH="+t.TempDir(), "GOWORK=off", "GOTOOLCHAIN=local")
+}
+
+// TestStarterBuildsAsModules runs go build ./... from the starter root and
+// inside submission, and go vet ./... inside memo and spec, which have no
+// main function to build.
+func TestStarterBuildsAsModules(t *testing.T) {
+    root := filepath.Join("..", "..")
+    env := cleanModuleEnv(t)
+    cmds := map[string][]string{"": {"build", "./..."}, "memo": {"vet", "./..."}, "spec": {"vet", "./..."}, "submission": {"build", "-o", os.DevNull, "./..."}}
+    for dir, args := range cmds {
+        cmd := exec.Command("go", args...)
+        cmd.Dir, cmd.Env = filepath.Join(root, dir), env
+        if msg, err := cmd.CombinedOutput(); err != nil { t.Errorf("go %s in ./%s: %v\n%s", strings.Join(args, " "), dir, err, msg) }
+    }
+}
+
+func TestModinit(t *testing.T) {
+    root := t.TempDir()
+    for _, dir := range []string{"main", "memo", "tools"} {
+        if err := os.Mkdir(filepath.Join(root, dir), 0o755); err != nil { t.Fatal(err) }
+    }
+    if _, err := modinit(root, "", "", true); err == nil { t.Fatal("modinit without a root go.mod or -module succeeded") }
+
+    stale, err := modinit(root, "example.com/starters/go-set", "1.27", true)
+    if err != nil { t.Fatal(err) }
+    want := []string{filepath.Join(root, "go.mod"), filepath.Join(root, "main", "go.mod"), filepath.Join(root, "memo", "go.mod")}
+    if !reflect.DeepEqual(stale, want) { t.Fatalf("wrote %v, want %v", stale, want) }
+    if mod, _ := os.ReadFile(filepath.Join(root, "memo", "go.mod")); string(mod) != "module example.com/starters/go-set/memo\n\ngo 1.27\n" { t.Errorf("memo/go.mod:\n%s", mod) }
+    if stale, err := modinit(root, "", "", false); err != nil || len(stale) > 0 { t.Fatalf("second run: stale %v, err %v", stale, err) }
+
+    // A new go version and a renamed module reach every file, and lines
+    // other than the two directives survive.
+    extra := "module example.com/starters/go-set\n\ngo 1.27\n\ntoolchain go1.27.1\n"
+    if err := os.WriteFile(filepath.Join(root, "go.mod"), []byte(extra), 0o644); err != nil { t.Fatal(err) }
+    if _, err := modinit(root, "example.com/go-set", "1.28", true); err != nil { t.Fatal(err) }
+    if mod, _ := os.ReadFile(filepath.Join(root, "go.mod")); string(mod) != "module example.com/go-set\n\ngo 1.28\n\ntoolchain go1.27.1\n" { t.Errorf("go.mod:\n%s", mod) }
+    if mod, _ := os.ReadFile(filepath.Join(root, "main", "go.mod")); string(mod) != "module example.com/go-set/main\n\ngo 1.28\n" { t.Errorf("main/go.mod:\n%s", mod) }
+
+    for _, bad := range [][2]string{{"example.com/go set", "1.27"}, {"example.com/go-set", "go1.27"}} {
+        if _, err := modinit(root, bad[0], bad[1], false); err == nil { t.Errorf("modinit(%q, %q) accepted it", bad[0], bad[1]) }
+    }
+}
+
+func TestRender(t *testing.T) {
+    cases := []struct{ cur, want string }{
+        {"", "module m\n\ngo 1.27\n"},
+        {"module old\n\ngo 1.20\n", "module m\n\ngo 1.27\n"},
+        {"go 1.20\n", "module m\n\ngo 1.27\n"},
+        {"module old\n", "module m\n\ngo 1.27\n"},
+        {"module old\n\ngo 1.20\n\nrequire example.com/x v1.0.0\n", "module m\n\ngo 1.27\n\nrequire example.com/x v1.0.0\n"},
+    }
+    for _, c := range cases {
+        if got := string(render([]byte(c.cur), "m", "1.27")); got != c.want { t.Errorf("render(%q) = %q, want %q", c.cur, got, c.want) }
+    }
+}
@@ -0,0 +1,139 @@
+// Command newstarter scaffolds a new Go starter: main/ with the shared
+// driver (output, section grammar, NOT IMPLEMENTED handling, -list-tasks and
+// -validate-sections) and a task table holding one example task, memo/ and
+// spec/ with the example's solution and stub, makefile/Makefile, a golden
+// transcript, and go.mod files laid out as tools/modinit keeps them (the
+// root's plus one per variant directory). The result passes tools/validate
+// as generated, so a
+// new starter begins from a working one instead of a hand-edited copy of
+// go-linkedlist:
+//
+//	go run ./tools/newstarter -name go-stack -module example.com/starters/go-stack [-go 1.27] [-out ../go-stack]
+//
+// The starter's file for students is named after it, without the "go-"
+// prefix (stack.go for go-stack). The shared driver files are embedded
+// copies of this starter's main/ and must be refreshed when those change;
+// TestSharedFilesMatchDriver fails until they are.
+package main
+
+//go:generate sh -c "for f in skel/main/*.copy; do n=$DOLLAR{f##*/}; cp ../../main/$DOLLAR{n%.copy} $DOLLAR{f}; done"
+
+import (
+    "bytes"
+    "embed"
+    "errors"
+    "flag"
+    "fmt"
+    "io/fs"
+    "os"
+    "path"
+    "path/filepath"
+    "regexp"
+    "runtime"
+    "strings"
+    "text/template"
+)
+
+// skel mirrors the generated starter. Files ending in .tmpl are rendered
+// with text/template and files ending in .copy are written unchanged; the
+// suffix is dropped either way, and memo/impl.go and spec/impl.go are
+// renamed to the starter's own file.
+//
+//go:embed skel
+var skel embed.FS
+
+// params are what the templates are rendered with.
+type params struct {
+    Name      string // starter directory name, such as go-stack
+    Module    string // module path in go.mod
+    GoVersion string // go directive in go.mod, such as 1.27
+    Impl      string // the file students submit, such as stack.go
+}
+
+var (
+    validName      = regexp.MustCompile(`^[a-z][a-z0-9]*(-[a-z0-9]+)*$`)
+    validModule    = regexp.MustCompile(`^[A-Za-z0-9._~-]+(/[A-Za-z0-9._~-]+)*$`)
+    validGoVersion = regexp.MustCompile(`^1\.[0-9]+(\.[0-9]+)?$`)
+)
+
+func main() {
+    name := flag.String("name", "", "starter name, such as go-stack")
+    module := flag.String("module", "", "module path for go.mod")
+    goVersion := flag.String("go", toolchainVersion(), "Go version for go.mod's go directive")
+    out := flag.String("out", "", "directory to create (default: the starter name)")
+    flag.Parse()
+
+    p, err := newParams(*name, *module, *goVersion)
+    if err == nil {
+        if *out == "" { *out = p.Name }
+        err = scaffold(*out, p)
+    }
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "newstarter:", err)
+        os.Exit(2)
+    }
+    fmt.Printf("newstarter: wrote %s; check it with go run ./tools/validate -root %s\n", *out, *out)
+}
+
+// toolchainVersion is the running Go's language version, such as 1.27.
+func toolchainVersion() string {
+    v := strings.TrimPrefix(runtime.Version(), "go")
+    if parts := strings.SplitN(v, ".", 3); len(parts) >= 2 { return parts[0] + "." + parts[1] }
+    return v
+}
+
+// newParams checks the command-line values and derives the student file name.
+func newParams(name, module, goVersion string) (params, error) {
+    if !validName.MatchString(name) { return params{}, fmt.Errorf("invalid -name %q (want lowercase words joined by '-', such as go-stack)", name) }
+    if !validModule.MatchString(module) { return params{}, fmt.Errorf("invalid -module %q (want a module path, such as example.com/starters/%s)", module, name) }
+    if !validGoVersion.MatchString(goVersion) { return params{}, fmt.Errorf("invalid -go %q (want a version such as 1.27)", goVersion) }
+    impl := strings.ReplaceAll(strings.TrimPrefix(name, "go-"), "-", "_") + ".go"
+    if impl == "main.go" { return params{}, fmt.Errorf("-name %q would name the student file main.go", name) }
+    return params{Name: name, Module: module, GoVersion: goVersion, Impl: impl}, nil
+}
+
+// scaffold writes the starter for p into dir, which must not exist yet or
+// be empty.
+func scaffold(dir string, p params) error {
+    if entries, err := os.ReadDir(dir); err == nil && len(entries) > 0 {
+        return fmt.Errorf("%s already exists and is not empty", dir)
+    }
+    files, err := render(p)
+    if err != nil { return err }
+    for rel, data := range files {
+        dst := filepath.Join(dir, filepath.FromSlash(rel))
+        if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil { return err }
+        if err := os.WriteFile(dst, data, 0o644); err != nil { return err }
+    }
+    return nil
+}
+
+// render returns the starter's files for p, keyed by slash-separated path.
+func render(p params) (map[string][]byte, error) {
+    files := map[string][]byte{}
+    err := fs.WalkDir(skel, "skel", func(name string, d fs.DirEntry, err error) error {
+        if err != nil || d.IsDir() { return err }
+        data, err := skel.ReadFile(name)
+        if err != nil { return err }
+        rel := strings.TrimPrefix(name, "skel/")
+        switch {
+        case strings.HasSuffix(rel, ".copy"):
+            rel = strings.TrimSuffix(rel, ".copy")
+        case strings.HasSuffix(rel, ".tmpl"):
+            rel = strings.TrimSuffix(rel, ".tmpl")
+            tmpl, err := template.New(rel).Option("missingkey=error").Parse(string(data))
+            if err != nil { return err }
+            var buf bytes.Buffer
+            if err := tmpl.Execute(&buf, p); err != nil { return err }
+            data = buf.Bytes()
+        default:
+            return fmt.Errorf("%s: skeleton files end in .tmpl or .copy", name)
+        }
+        if path.Base(rel) == "impl.go" { rel = path.Join(path.Dir(rel), p.Impl) }
+        files[rel] = data
+        return nil
+    })
+    if err != nil { return nil, err }
+    if len(files) == 0 { return nil, errors.New("the embedded skeleton is empty") }
+    return files, nil
+}
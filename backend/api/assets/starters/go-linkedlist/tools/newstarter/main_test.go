@@ -0,0 +1,128 @@
+package main
+
+import (
+    "bytes"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "strings"
+    "testing"
+)
+
+// TestSharedFilesMatchDriver checks that every embedded .copy file is
+// byte-for-byte the driver file it was taken from.
+func TestSharedFilesMatchDriver(t *testing.T) {
+    copies, err := filepath.Glob(filepath.Join("skel", "main", "*.copy"))
+    if err != nil { t.Fatal(err) }
+    if len(copies) == 0 { t.Fatal("no shared driver files in skel/main") }
+    for _, c := range copies {
+        name := strings.TrimSuffix(filepath.Base(c), ".copy")
+        want, err := os.ReadFile(filepath.Join("..", "..", "main", name))
+        if err != nil { t.Fatal(err) }
+        got, err := os.ReadFile(c)
+        if err != nil { t.Fatal(err) }
+        if !bytes.Equal(got, want) { t.Errorf("skel/main/%s.copy is stale (run go generate ./tools/newstarter from the starter root)", name) }
+    }
+}
+
+func TestNewParams(t *testing.T) {
+    p, err := newParams("go-binary-heap", "example.com/starters/go-binary-heap", "1.27")
+    if err != nil { t.Fatal(err) }
+    if p.Impl != "binary_heap.go" { t.Errorf("Impl = %q, want binary_heap.go", p.Impl) }
+    bad := [][3]string{
+        {"Go-Stack", "example.com/s", "1.27"},
+        {"go-stack", "example.com/s p", "1.27"},
+        {"go-stack", "example.com/s", "go1.27"},
+        {"go-main", "example.com/s", "1.27"},
+    }
+    for _, b := range bad {
+        if _, err := newParams(b[0], b[1], b[2]); err == nil { t.Errorf("newParams(%q, %q, %q) accepted it", b[0], b[1], b[2]) }
+    }
+}
+
+func TestScaffoldRefusesNonEmptyDir(t *testing.T) {
+    dir := t.TempDir()
+    if err := os.WriteFile(filepath.Join(dir, "keep"), nil, 0o644); err != nil { t.Fatal(err) }
+    p, _ := newParams("go-stack", "example.com/starters/go-stack", "1.27")
+    if err := scaffold(dir, p); err == nil { t.Fatal("scaffold wrote into a non-empty directory") }
+}
+
+var header = regexp.MustCompile(`^### (\S+)$`)
+
+// TestGeneratedStarterRuns scaffolds a starter, builds its driver against the
+// memo the way the grader lays the files out, and checks that each task's
+// transcript follows the section grammar and prints the sections -list-tasks
+// promises.
+func TestGeneratedStarterRuns(t *testing.T) {
+    p, err := newParams("go-stack", "example.com/starters/go-stack", "1.27")
+    if err != nil { t.Fatal(err) }
+    root := filepath.Join(t.TempDir(), p.Name)
+    if err := scaffold(root, p); err != nil { t.Fatal(err) }
+    if mod, _ := os.ReadFile(filepath.Join(root, "go.mod")); string(mod) != "module example.com/starters/go-stack\n\ngo 1.27\n" { t.Errorf("go.mod:\n%s", mod) }
+    if mk, _ := os.ReadFile(filepath.Join(root, "makefile", "Makefile")); !strings.Contains(string(mk), "SOURCES := main.go stack.go\n") { t.Errorf("Makefile does not list stack.go:\n%s", mk) }
+
+    stage := t.TempDir()
+    srcs, _ := filepath.Glob(filepath.Join(root, "main", "*.go"))
+    for _, src := range append(srcs, filepath.Join(root, "memo", p.Impl)) {
+        if err := os.Symlink(src, filepath.Join(stage, filepath.Base(src))); err != nil { t.Fatal(err) }
+    }
+    bin := filepath.Join(t.TempDir(), "app")
+    build := exec.Command("go", "build", "-o", bin, ".")
+    build.Dir = stage
+    build.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := build.CombinedOutput(); err != nil { t.Fatalf("go build: %v\n%s", err, msg) }
+
+    list, err := exec.Command(bin, "-list-tasks").Output()
+    if err != nil { t.Fatalf("-list-tasks: %v", err) }
+    if strings.TrimSpace(string(list)) != "task1 Task1Start Task1Empty" { t.Fatalf("-list-tasks = %q", list) }
+
+    got, err := exec.Command(bin, "task1").Output()
+    if err != nil { t.Fatalf("task1: %v", err) }
+    var names []string
+    for i, line := range strings.Split(strings.TrimSuffix(string(got), "\n"), "\n") {
+        if !strings.HasPrefix(line, "###") {
+            if len(names) == 0 { t.Fatalf("line %d: output before the first section header: %q", i+1, line) }
+            continue
+        }
+        m := header.FindStringSubmatch(line)
+        if m == nil { t.Fatalf("line %d: not a section header: %q", i+1, line) }
+        names = append(names, m[1])
+    }
+    if strings.Join(names, " ") != "Task1Start Task1Empty" { t.Errorf("task1 printed sections %v", names) }
+    golden, err := os.ReadFile(filepath.Join(root, "main", "testdata", "golden", "task1.txt"))
+    if err != nil { t.Fatal(err) }
+    if !bytes.Equal(got, golden) { t.Errorf("task1 output:\n%s\nwant the golden transcript:\n%s", got, golden) }
+}
+
+// TestGeneratedStarterIsModular checks that a fresh starter's go.mod files
+// are the ones tools/modinit would write and that it builds from its root in
+// module mode, with no GOPATH workspace to fall back on.
+func TestGeneratedStarterIsModular(t *testing.T) {
+    if testing.Short() { t.Skip("runs go run ../modinit") }
+    p, err := newParams("go-deque", "example.com/starters/go-deque", "1.27")
+    if err != nil { t.Fatal(err) }
+    root := filepath.Join(t.TempDir(), p.Name)
+    if err := scaffold(root, p); err != nil { t.Fatal(err) }
+    env := append(os.Environ(), "GO111MODULE=on", "GOPATH="+t.TempDir(), "GOWORK=off", "GOTOOLCHAIN=local")
+    check := exec.Command("go", "run", filepath.Join("..", "modinit"), "-root", root, "-check")
+    if msg, err := check.CombinedOutput(); err != nil { t.Fatalf("modinit -check: %v\n%s", err, msg) }
+    cmds := map[string][]string{"": {"build", "./..."}, "memo": {"vet", "./..."}, "spec": {"vet", "./..."}}
+    for dir, args := range cmds {
+        cmd := exec.Command("go", args...)
+        cmd.Dir, cmd.Env = filepath.Join(root, dir), env
+        if msg, err := cmd.CombinedOutput(); err != nil { t.Errorf("go %s in %s/%s: %v\n%s", strings.Join(args, " "), p.Name, dir, err, msg) }
+    }
+}
+
+// TestGeneratedStarterValidates runs tools/validate on a fresh starter.
+func TestGeneratedStarterValidates(t *testing.T) {
+    if testing.Short() { t.Skip("runs go run ../validate") }
+    p, err := newParams("go-queue", "example.com/starters/go-queue", "1.27")
+    if err != nil { t.Fatal(err) }
+    root := filepath.Join(t.TempDir(), p.Name)
+    if err := scaffold(root, p); err != nil { t.Fatal(err) }
+    cmd := exec.Command("go", "run", filepath.Join("..", "validate"), "-root", root)
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("validate: %v\n%s", err, msg) }
+}
@@ -0,0 +1,104 @@
+// Command selectimpl puts a submitted linked_list.go into the student slot
+// after checking that it is package main and that the driver builds against
+// it. The slot is app/linked_list_student.go, behind the student build tag
+// (see tools/syncmain, which has to have generated app/ first), so that
+//
+//	go build -tags student -o bin/main_student ./app
+//
+// builds the same driver code as the memo and spec builds. The submission is
+// copied to student/linked_list.go as well, the flat layout diffrun and the
+// grader compile. A submission that does not compile leaves both untouched
+// and the compiler's errors are printed instead.
+//
+// Run it from the starter root:
+//
+//	go run ./tools/selectimpl [-root .] [-student student] path/to/linked_list.go
+//
+// The exit status is 1 when the submission is rejected and 2 on other errors.
+package main
+
+import (
+    "encoding/json"
+    "errors"
+    "flag"
+    "fmt"
+    "go/build/constraint"
+    "go/parser"
+    "go/token"
+    "os"
+    "os/exec"
+    "path/filepath"
+)
+
+// errRejected marks a submission that is not valid driver input, as opposed
+// to a failure of selectimpl itself.
+var errRejected = errors.New("rejected")
+
+// slot is the student slot in app/, relative to the starter root.
+var slot = filepath.Join("app", "linked_list_student.go")
+
+// slotHeader constrains the slot to student builds.
+const slotHeader = "//go:build student\n\n"
+
+func main() {
+    root := flag.String("root", ".", "starter root holding app/")
+    studentDir := flag.String("student", "student", "flat student directory the submission is copied into as well")
+    flag.Parse()
+    if flag.NArg() != 1 {
+        fmt.Fprintln(os.Stderr, "usage: selectimpl [-root .] [-student student] linked_list.go")
+        os.Exit(2)
+    }
+
+    if err := selectImpl(*root, *studentDir, flag.Arg(0)); err != nil {
+        fmt.Fprintln(os.Stderr, "selectimpl:", err)
+        if errors.Is(err, errRejected) { os.Exit(1) }
+        os.Exit(2)
+    }
+    fmt.Printf("selectimpl: %s compiles against the driver\n", filepath.Join(*root, slot))
+}
+
+// selectImpl validates submission and copies it into root's student slot,
+// with the student build tag, and to studentDir/linked_list.go.
+func selectImpl(root, studentDir, submission string) error {
+    src, err := os.ReadFile(submission)
+    if err != nil { return err }
+    f, err := parser.ParseFile(token.NewFileSet(), submission, src, parser.PackageClauseOnly|parser.ParseComments)
+    if err != nil { return fmt.Errorf("%w: %v", errRejected, err) }
+    if f.Name.Name != "main" { return fmt.Errorf("%w: %s is package %s, want package main", errRejected, submission, f.Name.Name) }
+    for _, g := range f.Comments {
+        for _, c := range g.List {
+            if c.Pos() < f.Package && constraint.IsGoBuild(c.Text) { return fmt.Errorf("%w: %s has a //go:build line; the student slot sets its own", errRejected, submission) }
+        }
+    }
+
+    if _, err := os.Stat(filepath.Join(root, "app", "main.go")); err != nil {
+        return fmt.Errorf("%s has no driver; generate it with go run ./tools/syncmain: %v", filepath.Join(root, "app"), err)
+    }
+    tagged := append([]byte(slotHeader), src...)
+    if msg, err := build(root, tagged); err != nil {
+        return fmt.Errorf("%w: the driver does not build against %s: %v\n%s", errRejected, submission, err, msg)
+    }
+    if err := os.WriteFile(filepath.Join(root, slot), tagged, 0o644); err != nil { return err }
+    if err := os.MkdirAll(studentDir, 0o755); err != nil { return err }
+    return os.WriteFile(filepath.Join(studentDir, "linked_list.go"), src, 0o644)
+}
+
+// build runs go build -tags student ./app in root with src overlaid on the
+// slot, so the slot is only written once the build succeeds. It returns the
+// compiler output on failure.
+func build(root string, src []byte) ([]byte, error) {
+    dir, err := os.MkdirTemp("", "selectimpl")
+    if err != nil { return nil, err }
+    defer os.RemoveAll(dir)
+    abs, err := filepath.Abs(filepath.Join(root, slot))
+    if err != nil { return nil, err }
+    staged := filepath.Join(dir, filepath.Base(slot))
+    if err := os.WriteFile(staged, src, 0o644); err != nil { return nil, err }
+    overlay, err := json.Marshal(map[string]map[string]string{"Replace": {abs: staged}})
+    if err != nil { return nil, err }
+    if err := os.WriteFile(filepath.Join(dir, "overlay.json"), overlay, 0o644); err != nil { return nil, err }
+
+    cmd := exec.Command("go", "build", "-tags", "student", "-overlay", filepath.Join(dir, "overlay.json"), "-o", filepath.Join(dir, "app"), "./app")
+    cmd.Dir = root
+    return cmd.CombinedOutput()
+}
@@ -0,0 +1,92 @@
+package main
+
+import (
+    "errors"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+var root = filepath.Join("..", "..")
+
+func writeFile(t *testing.T, src string) string {
+    t.Helper()
+    path := filepath.Join(t.TempDir(), "linked_list.go")
+    if err := os.WriteFile(path, []byte(src), 0o644); err != nil { t.Fatal(err) }
+    return path
+}
+
+// tempRoot returns a starter root holding the module's go.mod and links to
+// main/, memo/ and spec/, with app/ generated by tools/syncmain and an empty
+// student slot.
+func tempRoot(t *testing.T) string {
+    t.Helper()
+    dir := t.TempDir()
+    for _, name := range []string{"go.mod", "main", "memo", "spec"} {
+        abs, err := filepath.Abs(filepath.Join(root, name))
+        if err != nil { t.Fatal(err) }
+        if err := os.Symlink(abs, filepath.Join(dir, name)); err != nil { t.Fatal(err) }
+    }
+    cmd := exec.Command("go", "run", "./tools/syncmain", "-root", dir)
+    cmd.Dir = root
+    if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("syncmain: %v\n%s", err, msg) }
+    return dir
+}
+
+func TestSelectImplCopiesValidSubmission(t *testing.T) {
+    spec, err := os.ReadFile(filepath.Join(root, "spec", "linked_list.go"))
+    if err != nil { t.Fatal(err) }
+    dir := tempRoot(t)
+    student := filepath.Join(t.TempDir(), "student")
+    if err := selectImpl(dir, student, writeFile(t, string(spec))); err != nil { t.Fatal(err) }
+    got, err := os.ReadFile(filepath.Join(dir, slot))
+    if err != nil || string(got) != slotHeader+string(spec) { t.Fatalf("slot holds %d bytes (err %v), want the tagged submission", len(got), err) }
+    got, err = os.ReadFile(filepath.Join(student, "linked_list.go"))
+    if err != nil || string(got) != string(spec) { t.Fatalf("student/ holds %d bytes (err %v), want the submission", len(got), err) }
+}
+
+// TestSelectImplNeedsApp checks that a root whose app/ was never generated
+// is reported as such rather than as a bad submission.
+func TestSelectImplNeedsApp(t *testing.T) {
+    err := selectImpl(t.TempDir(), filepath.Join(t.TempDir(), "student"), writeFile(t, "package main\n"))
+    if err == nil || errors.Is(err, errRejected) || !strings.Contains(err.Error(), "syncmain") { t.Fatalf("err = %v, want a hint to run tools/syncmain", err) }
+}
+
+func TestSelectImplRejects(t *testing.T) {
+    cases := []struct{ name, src, msg string }{
+        {"wrong package", "package list\n", "is package list, want package main"},
+        {"syntax error", "package main\nfunc {\n", "expected"},
+        {"build line", "//go:build memo\n\npackage main\n", "has a //go:build line"},
+        {"missing methods", "package main\ntype LinkedList struct{}\nfunc New() *LinkedList { return nil }\n", "does not build"},
+    }
+    for _, c := range cases {
+        dir := tempRoot(t)
+        student := filepath.Join(t.TempDir(), "student")
+        err := selectImpl(dir, student, writeFile(t, c.src))
+        if !errors.Is(err, errRejected) || !strings.Contains(err.Error(), c.msg) { t.Errorf("%s: err = %v, want a rejection containing %q", c.name, err, c.msg) }
+        if _, err := os.Stat(filepath.Join(dir, slot)); !os.IsNotExist(err) { t.Errorf("%s: rejected submission filled the slot", c.name) }
+        if _, err := os.Stat(student); !os.IsNotExist(err) { t.Errorf("%s: rejected submission created student/", c.name) }
+    }
+}
+
+// TestVariantsShareTheDriver fills the student slot with the memo through
+// selectImpl, builds app/ with no tag (the spec), -tags memo and -tags
+// student, and checks all three register the same tasks and sections.
+func TestVariantsShareTheDriver(t *testing.T) {
+    dir := tempRoot(t)
+    if err := selectImpl(dir, filepath.Join(t.TempDir(), "student"), filepath.Join(root, "memo", "linked_list.go")); err != nil { t.Fatal(err) }
+    var want string
+    for _, tags := range []string{"memo", "", "student"} {
+        bin := filepath.Join(t.TempDir(), "app")
+        cmd := exec.Command("go", "build", "-tags", tags, "-o", bin, "./app")
+        cmd.Dir = dir
+        if msg, err := cmd.CombinedOutput(); err != nil { t.Fatalf("-tags %q: %v\n%s", tags, err, msg) }
+        out, err := exec.Command(bin, "-list-tasks").Output()
+        if err != nil { t.Fatalf("-tags %q -list-tasks: %v", tags, err) }
+        if want == "" { want = string(out); continue }
+        if string(out) != want { t.Errorf("-tags %q -list-tasks:\n%s\nmemo:\n%s", tags, out, want) }
+    }
+    if !strings.HasPrefix(want, "task1 Task1Start") { t.Fatalf("unexpected -list-tasks output:\n%s", want) }
+}
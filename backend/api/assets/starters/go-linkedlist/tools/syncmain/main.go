@@ -0,0 +1,116 @@
+// Command syncmain generates app/, the driver as a package of the root
+// module with the list implementation picked by build tag: none for the
+// spec skeleton (linked_list_spec.go), memo for the memo
+// (linked_list_memo.go) and student for the file tools/selectimpl puts in
+// the slot. From the starter root:
+//
+//	go build -tags memo ./...
+//	go build -tags student -o bin/main_student ./app
+//
+// Every non-test main/*.go is copied into app/ unchanged, so all three
+// binaries come from identical driver code, and memo/ and spec/
+// linked_list.go are copied in behind their tags. app/ is generated, like
+// .build/, and not committed: test.sh regenerates it before building the
+// root module, and after changing main/, memo/ or spec/ so should anyone
+// building it by hand. Files app/ should no longer hold are removed; the
+// student slot, app/linked_list_student.go, is left alone.
+//
+// Run it from the starter root, or through go generate:
+//
+//	go run ./tools/syncmain [-root .] [-app app]
+package main
+
+//go:generate go run . -root ../..
+
+import (
+    "bytes"
+    "flag"
+    "fmt"
+    "os"
+    "path/filepath"
+    "regexp"
+    "sort"
+    "strings"
+)
+
+// studentFile is app/'s student slot, which tools/selectimpl fills.
+const studentFile = "linked_list_student.go"
+
+// appImpls are the implementations copied into app/, each as dst behind
+// its build constraint. The spec is the untagged default, so go build ./...
+// from the starter root compiles.
+var appImpls = []struct{ src, dst, tag string }{
+    {filepath.Join("memo", "linked_list.go"), "linked_list_memo.go", "memo"},
+    {filepath.Join("spec", "linked_list.go"), "linked_list_spec.go", "!memo && !student"},
+}
+
+func main() {
+    root := flag.String("root", ".", "starter root holding main/, memo/ and spec/")
+    app := flag.String("app", "app", "directory, relative to the root, of the build-tag driver package to generate")
+    flag.Parse()
+
+    changed, err := syncApp(*root, *app)
+    if err != nil {
+        fmt.Fprintln(os.Stderr, "syncmain:", err)
+        os.Exit(2)
+    }
+    fmt.Printf("syncmain: generated %s (%d files changed)\n", filepath.Join(*root, *app), len(changed))
+}
+
+// syncApp brings root/app to what it should hold (see the package doc) and
+// returns the files it wrote or removed, in name order.
+func syncApp(root, app string) ([]string, error) {
+    want := map[string][]byte{}
+    drivers, err := filepath.Glob(filepath.Join(root, "main", "*.go"))
+    if err != nil { return nil, err }
+    if len(drivers) == 0 { return nil, fmt.Errorf("no driver sources in %s", filepath.Join(root, "main")) }
+    for _, path := range drivers {
+        if strings.HasSuffix(path, "_test.go") { continue }
+        if want[filepath.Base(path)], err = os.ReadFile(path); err != nil { return nil, err }
+    }
+    for _, impl := range appImpls {
+        src, err := os.ReadFile(filepath.Join(root, impl.src))
+        if err != nil { return nil, err }
+        want[impl.dst] = render(src, impl.tag)
+    }
+
+    dir := filepath.Join(root, app)
+    have, err := filepath.Glob(filepath.Join(dir, "*.go"))
+    if err != nil { return nil, err }
+    names := map[string]bool{}
+    for name := range want { names[name] = true }
+    for _, path := range have {
+        if name := filepath.Base(path); name != studentFile { names[name] = true }
+    }
+    sorted := make([]string, 0, len(names))
+    for name := range names { sorted = append(sorted, name) }
+    sort.Strings(sorted)
+
+    var changed []string
+    for _, name := range sorted {
+        path := filepath.Join(dir, name)
+        src, keep := want[name]
+        cur, err := os.ReadFile(path)
+        if keep && err == nil && bytes.Equal(cur, src) { continue }
+        changed = append(changed, path)
+        if !keep {
+            if err := os.Remove(path); err != nil { return nil, err }
+            continue
+        }
+        if err := os.MkdirAll(dir, 0o755); err != nil { return nil, err }
+        if err := os.WriteFile(path, src, 0o644); err != nil { return nil, err }
+    }
+    return changed, nil
+}
+
+var leadingBuild = regexp.MustCompile(`\A//go:build [^\n]*\n\n?`)
+
+// render returns src behind //go:build tag, in place of any //go:build line
+// it starts with. Everything else, formatting included, is left exactly as
+// it is.
+func render(src []byte, tag string) []byte {
+    var out bytes.Buffer
+    fmt.Fprintf(&out, "//go:build %s\n\n", tag)
+    out.Write(leadingBuild.ReplaceAll(src, nil))
+    return out.Bytes()
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "reflect"
+    "testing"
+)
+
+const source = "// Driver doc.\npackage main\n\nimport \"fmt\"\n\nfunc main() {\n\tfmt.Println(\"package x\")   // odd  spacing kept\n}\n"
+
+func TestRender(t *testing.T) {
+    cases := []struct{ src, tag, want string }{
+        {source, "memo", "//go:build memo\n\n" + source},
+        {"//go:build secret\n\n" + source, "memo", "//go:build memo\n\n" + source},
+        {"//go:build secret\n" + source, "!memo && !student", "//go:build !memo && !student\n\n" + source},
+    }
+    for _, c := range cases {
+        if got := string(render([]byte(c.src), c.tag)); got != c.want { t.Errorf("render(%q) =\n%s\nwant:\n%s", c.tag, got, c.want) }
+    }
+}
+
+func writeFile(t *testing.T, path, src string) {
+    t.Helper()
+    if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil { t.Fatal(err) }
+    if err := os.WriteFile(path, []byte(src), 0o644); err != nil { t.Fatal(err) }
+}
+
+// TestSyncApp plants a divergent driver copy, a left-over file and a filled
+// student slot in app/: syncApp must fix the first two, leave the slot
+// alone and never copy test files, and a second run must change nothing.
+func TestSyncApp(t *testing.T) {
+    root := t.TempDir()
+    writeFile(t, filepath.Join(root, "main", "main.go"), source)
+    writeFile(t, filepath.Join(root, "main", "build_memo.go"), "//go:build memo\n\npackage main\n")
+    writeFile(t, filepath.Join(root, "main", "main_test.go"), "package main\n")
+    writeFile(t, filepath.Join(root, "memo", "linked_list.go"), "package main\n\ntype LinkedList struct{}\n")
+    writeFile(t, filepath.Join(root, "spec", "linked_list.go"), "// Spec.\n\npackage main\n")
+    app := filepath.Join(root, "app")
+    writeFile(t, filepath.Join(app, "main.go"), source+"// stale\n")
+    writeFile(t, filepath.Join(app, "removed.go"), "package main\n")
+    writeFile(t, filepath.Join(app, studentFile), "//go:build student\n\npackage main\n")
+    want := []string{
+        filepath.Join(app, "build_memo.go"),
+        filepath.Join(app, "linked_list_memo.go"),
+        filepath.Join(app, "linked_list_spec.go"),
+        filepath.Join(app, "main.go"),
+        filepath.Join(app, "removed.go"),
+    }
+
+    changed, err := syncApp(root, "app")
+    if err != nil || !reflect.DeepEqual(changed, want) { t.Fatalf("syncApp changed %v (err %v), want %v", changed, err, want) }
+    files := map[string]string{
+        "main.go":             source,
+        "build_memo.go":       "//go:build memo\n\npackage main\n",
+        "linked_list_memo.go": "//go:build memo\n\npackage main\n\ntype LinkedList struct{}\n",
+        "linked_list_spec.go": "//go:build !memo && !student\n\n// Spec.\n\npackage main\n",
+        studentFile:           "//go:build student\n\npackage main\n",
+    }
+    for name, src := range files {
+        if got, _ := os.ReadFile(filepath.Join(app, name)); string(got) != src { t.Errorf("app/%s:\n%s\nwant:\n%s", name, got, src) }
+    }
+    for _, gone := range []string{"removed.go", "main_test.go"} {
+        if _, err := os.Stat(filepath.Join(app, gone)); !os.IsNotExist(err) { t.Errorf("app/%s exists", gone) }
+    }
+    if changed, err = syncApp(root, "app"); err != nil || len(changed) > 0 { t.Fatalf("second syncApp changed %v (err %v)", changed, err) }
+}
+
+// TestSyncAppCreatesApp generates app/ where there is none, as in a fresh
+// checkout.
+func TestSyncAppCreatesApp(t *testing.T) {
+    root := t.TempDir()
+    writeFile(t, filepath.Join(root, "main", "main.go"), source)
+    writeFile(t, filepath.Join(root, "memo", "linked_list.go"), "package main\n")
+    writeFile(t, filepath.Join(root, "spec", "linked_list.go"), "package main\n")
+    changed, err := syncApp(root, "app")
+    if err != nil { t.Fatal(err) }
+    if len(changed) != 3 { t.Fatalf("syncApp wrote %v, want main.go and both implementations", changed) }
+    if _, err := syncApp(root, "app"); err != nil { t.Fatal(err) }
+    if _, err := syncApp(t.TempDir(), "app"); err == nil { t.Error("syncApp accepted a root with no main/") }
+}
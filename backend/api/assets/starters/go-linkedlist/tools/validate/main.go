@@ -0,0 +1,241 @@
+// Command validate checks a starter end to end before students see it. It
+// builds the driver against the memo and against the spec, asks the memo
+// build for its tasks with -list-tasks and runs each one, then checks
+//
+//   - that the memo exits cleanly and its transcript follows the delimiter
+//     grammar: no output before the first header, every header a "### name"
+//     line and no name repeated,
+//   - that the sections it prints are the ones -list-tasks promised, in order,
+//   - that it matches the golden transcript under main/testdata/golden, when
+//     the starter has one for the task, and
+//   - that the spec build exits cleanly and differs from the memo only in
+//     sections its stubs ended (NOT IMPLEMENTED or NOT RUN).
+//
+// It prints one PASS or FAIL line per check and exits 1 if any failed:
+//
+//	go run ./tools/validate [-root .]
+package main
+
+import (
+    "bytes"
+    "errors"
+    "flag"
+    "fmt"
+    "os"
+    "os/exec"
+    "path/filepath"
+    "regexp"
+    "strings"
+)
+
+// delim opens a section header line.
+const delim = "### "
+
+// The lines the driver prints when a spec stub panics.
+const (
+    notImplemented = "NOT IMPLEMENTED: "
+    notRun         = "NOT RUN: "
+)
+
+var headerLine = regexp.MustCompile(`^### (\S+)$`)
+
+// check is one line of the report: what was checked and why it failed, or a
+// nil err when it passed.
+type check struct {
+    name string
+    err  error
+}
+
+func main() {
+    root := flag.String("root", ".", "starter root holding main/, memo/ and spec/")
+    flag.Parse()
+
+    checks := validate(*root)
+    failed := 0
+    for _, c := range checks {
+        if c.err == nil { fmt.Printf("PASS %s\n", c.name); continue }
+        failed++
+        fmt.Printf("FAIL %s: %v\n", c.name, c.err)
+    }
+    fmt.Printf("validate: %d checks, %d failed\n", len(checks), failed)
+    if failed > 0 { os.Exit(1) }
+}
+
+// validate runs every check against the starter at root, in report order.
+// Checks that need a binary which did not build are left out.
+func validate(root string) []check {
+    tmp, err := os.MkdirTemp("", "validate")
+    if err != nil { return []check{{"set up", err}} }
+    defer os.RemoveAll(tmp)
+    memoBin, specBin := filepath.Join(tmp, "memo"), filepath.Join(tmp, "spec")
+    mainDir := filepath.Join(root, "main")
+    impl, err := implFiles(root)
+    if err != nil { return []check{{"read Makefile", err}} }
+
+    checks := []check{
+        {"build memo", build(mainDir, filepath.Join(root, "memo"), impl, memoBin)},
+        {"build spec", build(mainDir, filepath.Join(root, "spec"), impl, specBin)},
+    }
+    if checks[0].err != nil { return checks }
+    specBuilt := checks[1].err == nil
+
+    tasks, err := listTasks(memoBin)
+    checks = append(checks, check{"list tasks", err})
+    if err != nil { return checks }
+    for _, t := range tasks {
+        out, err := run(memoBin, t.name)
+        if err == nil { err = checkTranscript(out, t.sections) }
+        checks = append(checks, check{"memo " + t.name, err})
+        if err != nil { continue }
+
+        golden := filepath.Join(mainDir, "testdata", "golden", t.name+".txt")
+        if want, err := os.ReadFile(golden); err == nil {
+            checks = append(checks, check{"golden " + t.name, diffSections(parseSections(string(want)), parseSections(out), "golden", "memo", false)})
+        }
+        if !specBuilt { continue }
+        got, err := run(specBin, t.name)
+        if err == nil { err = diffSections(parseSections(out), parseSections(got), "memo", "spec", true) }
+        checks = append(checks, check{"spec " + t.name, err})
+    }
+    return checks
+}
+
+var sourcesLine = regexp.MustCompile(`(?m)^SOURCES\s*:?=(.*)$`)
+
+// implFiles returns the files the grader compiles next to the driver: the
+// SOURCES of the starter's Makefile other than main.go. A starter without a
+// Makefile is taken to have go-linkedlist's single linked_list.go.
+func implFiles(root string) ([]string, error) {
+    makefile, err := os.ReadFile(filepath.Join(root, "makefile", "Makefile"))
+    if os.IsNotExist(err) { return []string{"linked_list.go"}, nil }
+    if err != nil { return nil, err }
+    m := sourcesLine.FindSubmatch(makefile)
+    if m == nil { return nil, errors.New("the Makefile has no SOURCES line") }
+    var files []string
+    for _, f := range strings.Fields(string(m[1])) {
+        if f != "main.go" { files = append(files, f) }
+    }
+    if len(files) == 0 { return nil, errors.New("the Makefile's SOURCES name no file besides main.go") }
+    return files, nil
+}
+
+// build stages the driver's non-test sources next to implDir's impl files,
+// the layout the grader compiles, and builds bin from them.
+func build(mainDir, implDir string, impl []string, bin string) error {
+    srcs, err := filepath.Glob(filepath.Join(mainDir, "*.go"))
+    if err != nil { return err }
+    if len(srcs) == 0 { return fmt.Errorf("no driver sources in %s", mainDir) }
+    stage, err := os.MkdirTemp("", "validate-build")
+    if err != nil { return err }
+    defer os.RemoveAll(stage)
+    for _, f := range impl { srcs = append(srcs, filepath.Join(implDir, f)) }
+    for _, src := range srcs {
+        if strings.HasSuffix(src, "_test.go") { continue }
+        abs, err := filepath.Abs(src)
+        if err != nil { return err }
+        if err := os.Symlink(abs, filepath.Join(stage, filepath.Base(src))); err != nil { return err }
+    }
+    cmd := exec.Command("go", "build", "-o", bin, ".")
+    cmd.Dir = stage
+    cmd.Env = append(os.Environ(), "GO111MODULE=off")
+    if msg, err := cmd.CombinedOutput(); err != nil { return fmt.Errorf("go build: %v\n%s", err, strings.TrimSpace(string(msg))) }
+    return nil
+}
+
+// task is one line of -list-tasks: a task name and the sections it emits.
+type task struct {
+    name     string
+    sections []string
+}
+
+func listTasks(bin string) ([]task, error) {
+    stdout, err := exec.Command(bin, "-list-tasks").Output()
+    if err != nil { return nil, fmt.Errorf("-list-tasks: %w", err) }
+    var tasks []task
+    for _, line := range strings.Split(strings.TrimSpace(string(stdout)), "\n") {
+        fields := strings.Fields(line)
+        if len(fields) < 2 { return nil, fmt.Errorf("-list-tasks printed %q, want a task and its sections", line) }
+        tasks = append(tasks, task{fields[0], fields[1:]})
+    }
+    return tasks, nil
+}
+
+// run runs one task and returns its stdout; a non-zero exit is an error
+// carrying the first line of stderr.
+func run(bin, name string) (string, error) {
+    var stdout, stderr bytes.Buffer
+    cmd := exec.Command(bin, name)
+    cmd.Stdout, cmd.Stderr = &stdout, &stderr
+    if err := cmd.Run(); err != nil {
+        first, _, _ := strings.Cut(strings.TrimSpace(stderr.String()), "\n")
+        return "", fmt.Errorf("%s exited with %v: %s", name, err, first)
+    }
+    return stdout.String(), nil
+}
+
+// checkTranscript checks a task's stdout against the delimiter grammar and
+// against the sections -list-tasks gave for it.
+func checkTranscript(out string, want []string) error {
+    var names []string
+    seen := map[string]int{}
+    for i, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+        n := i + 1
+        if !strings.HasPrefix(line, "###") {
+            if len(names) == 0 { return fmt.Errorf("line %d: output before the first section header: %q", n, line) }
+            continue
+        }
+        m := headerLine.FindStringSubmatch(line)
+        if m == nil { return fmt.Errorf("line %d: starts with the delimiter but is not a section header: %q", n, line) }
+        if first, dup := seen[m[1]]; dup { return fmt.Errorf("line %d: section %s already opened on line %d", n, m[1], first) }
+        seen[m[1]] = n
+        names = append(names, m[1])
+    }
+    if strings.Join(names, " ") != strings.Join(want, " ") {
+        return fmt.Errorf("printed sections %v, -list-tasks promised %v", names, want)
+    }
+    return nil
+}
+
+// section is a parsed section: its name and its body lines.
+type section struct {
+    name  string
+    lines []string
+}
+
+func parseSections(out string) []section {
+    var secs []section
+    for _, line := range strings.Split(strings.TrimSuffix(out, "\n"), "\n") {
+        if name, ok := strings.CutPrefix(line, delim); ok {
+            secs = append(secs, section{name: name})
+        } else if len(secs) > 0 {
+            secs[len(secs)-1].lines = append(secs[len(secs)-1].lines, line)
+        }
+    }
+    return secs
+}
+
+// diffSections compares two transcripts section by section and reports the
+// first difference. With stubs set, a got section whose last line is a
+// NOT IMPLEMENTED or NOT RUN line is allowed to differ.
+func diffSections(want, got []section, wantName, gotName string, stubs bool) error {
+    if len(want) != len(got) { return fmt.Errorf("%s has %d sections, %s %d", wantName, len(want), gotName, len(got)) }
+    for i, w := range want {
+        g := got[i]
+        if w.name != g.name { return fmt.Errorf("section %d is %s in %s, %s in %s", i+1, w.name, wantName, g.name, gotName) }
+        if strings.Join(w.lines, "\n") == strings.Join(g.lines, "\n") { continue }
+        if stubs && len(g.lines) > 0 {
+            last := g.lines[len(g.lines)-1]
+            if strings.HasPrefix(last, notImplemented) || strings.HasPrefix(last, notRun) { continue }
+        }
+        return errors.New(firstDiff(w, g, wantName, gotName))
+    }
+    return nil
+}
+
+// firstDiff describes the first line where two versions of a section differ.
+func firstDiff(w, g section, wantName, gotName string) string {
+    for j := 0; j < len(w.lines) && j < len(g.lines); j++ {
+        if w.lines[j] != g.lines[j] { return fmt.Sprintf("%s line %d: %s %q, %s %q", w.name, j+1, wantName, w.lines[j], gotName, g.lines[j]) }
+    }
+    return fmt.Sprintf("%s: %s has %d lines, %s %d", w.name, wantName, len(w.lines), gotName, len(g.lines))
+}
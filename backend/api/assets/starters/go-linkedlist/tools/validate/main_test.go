@@ -0,0 +1,152 @@
+package main
+
+import (
+    "os"
+    "path/filepath"
+    "strings"
+    "testing"
+)
+
+var starterRoot = filepath.Join("..", "..")
+
+func TestStarterValidates(t *testing.T) {
+    checks := validate(starterRoot)
+    if len(checks) < 4 { t.Fatalf("only %d checks ran: %v", len(checks), checks) }
+    for _, c := range checks {
+        if c.err != nil { t.Errorf("%s: %v", c.name, c.err) }
+    }
+}
+
+// copyStarter copies what validate reads (the driver sources and golden
+// transcripts, and the memo's and spec's linked_list.go) into a new root,
+// applying edit to each file's content on the way.
+func copyStarter(t *testing.T, edit func(path, src string) string) string {
+    t.Helper()
+    root := t.TempDir()
+    err := filepath.Walk(starterRoot, func(path string, info os.FileInfo, err error) error {
+        if err != nil { return err }
+        rel, err := filepath.Rel(starterRoot, path)
+        if err != nil { return err }
+        keep := filepath.Dir(rel) == "main" && strings.HasSuffix(rel, ".go") && !strings.HasSuffix(rel, "_test.go") ||
+            strings.HasPrefix(rel, filepath.Join("main", "testdata", "golden")+string(filepath.Separator)) ||
+            rel == filepath.Join("memo", "linked_list.go") || rel == filepath.Join("spec", "linked_list.go")
+        if info.IsDir() || !keep { return nil }
+        data, err := os.ReadFile(path)
+        if err != nil { return err }
+        dst := filepath.Join(root, rel)
+        if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil { return err }
+        return os.WriteFile(dst, []byte(edit(filepath.ToSlash(rel), string(data))), 0o644)
+    })
+    if err != nil { t.Fatal(err) }
+    return root
+}
+
+// replaceIn returns an edit that replaces old with new in the file at rel,
+// failing the test if old is not there.
+func replaceIn(t *testing.T, rel, old, new string) func(string, string) string {
+    return func(path, src string) string {
+        if path != rel { return src }
+        if !strings.Contains(src, old) { t.Fatalf("%s does not contain %q", rel, old) }
+        return strings.Replace(src, old, new, 1)
+    }
+}
+
+// TestBrokenStarters breaks a copy of the starter in one way each and checks
+// that exactly the expected checks fail, with the expected reason.
+func TestBrokenStarters(t *testing.T) {
+    cases := []struct {
+        name string
+        edit func(string, string) string
+        fail map[string]string // check name -> substring of its error
+    }{
+        {
+            "memo PopFront leaves tail behind",
+            replaceIn(t, "memo/linked_list.go", "    if l.head == nil { l.tail = nil }\n    l.size--", "    l.size--"),
+            map[string]string{"golden task1": `Task1PopLastThenPush line 3: golden "after-pop-last-then-push: [99] size=1"`},
+        },
+        {
+            "section name with a space",
+            replaceIn(t, "main/main.go", `r.section(subtask("Task1", "clear"), "clear the list")`, `r.section("Task1 Clear", "clear the list")`),
+            map[string]string{"memo task1": `not a section header: "### Task1 Clear"`},
+        },
+        {
+            "section missing from -list-tasks",
+            replaceIn(t, "main/main.go", `"clear", "pop_last_then_push"}`, `"pop_last_then_push"}`),
+            map[string]string{"memo task1": "-list-tasks promised"},
+        },
+        {
+            "spec does not compile",
+            replaceIn(t, "spec/linked_list.go", "func (l *LinkedList) PushBack(v int)", "func (l *LinkedList) PushBack(v string)"),
+            map[string]string{"build spec": "go build"},
+        },
+        {
+            "spec gives away a wrong Len",
+            replaceIn(t, "spec/linked_list.go", `func (l *LinkedList) Len() int { return l.size }`, `func (l *LinkedList) Len() int { return l.size + 1 }`),
+            map[string]string{"spec task1": `Task1EmptyList line 1: memo "empty=true size=0", spec "empty=true size=1"`},
+        },
+        {
+            "spec constructor returns nil",
+            replaceIn(t, "spec/linked_list.go", `func New() *LinkedList { return &LinkedList{} }`, `func New() *LinkedList { return nil }`),
+            map[string]string{"spec task1": "exited with"},
+        },
+    }
+    for _, c := range cases {
+        t.Run(c.name, func(t *testing.T) {
+            checks := validate(copyStarter(t, c.edit))
+            got := map[string]bool{}
+            for _, ch := range checks {
+                want, shouldFail := c.fail[ch.name]
+                switch {
+                case ch.err == nil && shouldFail: t.Errorf("%s passed", ch.name)
+                case ch.err != nil && !shouldFail: t.Errorf("%s failed: %v", ch.name, ch.err)
+                case ch.err != nil && !strings.Contains(ch.err.Error(), want): t.Errorf("%s: error %q does not mention %q", ch.name, ch.err, want)
+                }
+                got[ch.name] = true
+            }
+            for name := range c.fail {
+                if !got[name] { t.Errorf("check %s did not run", name) }
+            }
+        })
+    }
+}
+
+func TestImplFiles(t *testing.T) {
+    root := t.TempDir()
+    if got, err := implFiles(root); err != nil || strings.Join(got, " ") != "linked_list.go" { t.Errorf("no Makefile: implFiles = %v, %v", got, err) }
+    if err := os.MkdirAll(filepath.Join(root, "makefile"), 0o755); err != nil { t.Fatal(err) }
+    for mk, want := range map[string]string{
+        "SOURCES := main.go stack.go node.go\n": "stack.go node.go",
+        "BINARY := app\n":                       "error",
+        "SOURCES := main.go\n":                  "error",
+    } {
+        if err := os.WriteFile(filepath.Join(root, "makefile", "Makefile"), []byte(mk), 0o644); err != nil { t.Fatal(err) }
+        got, err := implFiles(root)
+        if want == "error" {
+            if err == nil { t.Errorf("%q: implFiles = %v, want an error", mk, got) }
+        } else if err != nil || strings.Join(got, " ") != want { t.Errorf("%q: implFiles = %v, %v, want %s", mk, got, err, want) }
+    }
+}
+
+func TestCheckTranscript(t *testing.T) {
+    want := []string{"A", "B"}
+    cases := []struct{ out, err string }{
+        {"### A\nx\n### B\n", ""},
+        {"hello\n### A\n### B\n", "line 1: output before the first section header"},
+        {"### A\n###B\n", "line 2: starts with the delimiter but is not a section header"},
+        {"### A\n### A\n", "line 2: section A already opened on line 1"},
+        {"### B\n### A\n", "printed sections [B A], -list-tasks promised [A B]"},
+    }
+    for _, c := range cases {
+        err := checkTranscript(c.out, want)
+        if c.err == "" && err != nil || c.err != "" && (err == nil || !strings.Contains(err.Error(), c.err)) { t.Errorf("checkTranscript(%q) = %v, want %q", c.out, err, c.err) }
+    }
+}
+
+func TestDiffSectionsAllowsStubsOnlyWhenAsked(t *testing.T) {
+    memo := parseSections("### A\n[1] size=1\n### B\nok\n")
+    spec := parseSections("### A\nNOT IMPLEMENTED: PushBack\n### B\nNOT RUN: PushBack is not implemented\n")
+    if err := diffSections(memo, spec, "memo", "spec", true); err != nil { t.Errorf("stubbed sections rejected: %v", err) }
+    if err := diffSections(memo, spec, "memo", "spec", false); err == nil { t.Error("stubbed sections accepted without stubs set") }
+    wrong := parseSections("### A\n[2] size=1\n### B\nok\n")
+    if err := diffSections(memo, wrong, "memo", "spec", true); err == nil || !strings.Contains(err.Error(), `A line 1: memo "[1] size=1", spec "[2] size=1"`) { t.Errorf("wrong output: err = %v", err) }
+}
@@ -35,6 +35,11 @@ use util::{
 
 static STARTERS_ROOT: Dir<'static> = include_dir!("$CARGO_MANIFEST_DIR/assets/starters");
 
+/// Extra pack subdirs zipped, under their own name, into another subdir's zip.
+/// Grading-only sources in secret/ go into makefile.zip next to the Makefile
+/// target that builds them, and so never into main.zip, which students get.
+const BUNDLED_WITH: &[(&str, &str)] = &[("makefile", "secret")];
+
 #[derive(Deserialize)]
 pub struct StarterReq {
     /// Starter pack ID (matches directory under assets/starters/<id>/)
@@ -277,7 +282,16 @@ async fn install_zip_if_present_root(
         return Ok(());
     };
 
-    let bytes = zip_dir_flat(dir).map_err(|e| format!("zip failed: {e}"))?;
+    let mut dirs = vec![(dir, String::new())];
+    for (owner, extra) in BUNDLED_WITH {
+        if *owner != subdir {
+            continue;
+        }
+        if let Some(d) = STARTERS_ROOT.get_dir(&format!("{}/{}", pack_id, extra)) {
+            dirs.push((d, extra.to_string()));
+        }
+    }
+    let bytes = zip_dirs_flat(&dirs).map_err(|e| format!("zip failed: {e}"))?;
 
     AssignmentFileModel::save_file(db, assignment_id, module_id, file_type, out_name, &bytes)
         .await
@@ -286,8 +300,20 @@ async fn install_zip_if_present_root(
     Ok(())
 }
 
-/// Zip the embedded dir so files are at ZIP ROOT (no extra top-level folder).
-fn zip_dir_flat(d: &Dir<'_>) -> Result<Vec<u8>, std::io::Error> {
+/// Whether an embedded file or dir only serves the starter's own tests and is
+/// left out of the zips: testdata/ dirs (golden transcripts, JSON snapshots)
+/// and Go test files, as the Go starter's tools/bundle does.
+fn is_dev_only(name: &str, is_dir: bool) -> bool {
+    if is_dir {
+        name == "testdata"
+    } else {
+        name.ends_with("_test.go")
+    }
+}
+
+/// Zip the embedded dirs, each under its prefix; an empty prefix puts files at
+/// ZIP ROOT (no extra top-level folder).
+fn zip_dirs_flat(dirs: &[(&Dir<'_>, String)]) -> Result<Vec<u8>, std::io::Error> {
     use zip::{CompressionMethod, ZipWriter};
 
     let mut buf = std::io::Cursor::new(Vec::<u8>::new());
@@ -301,20 +327,23 @@ fn zip_dir_flat(d: &Dir<'_>) -> Result<Vec<u8>, std::io::Error> {
         opts: FileOptions<()>,
     ) -> std::io::Result<()> {
         for f in dir.files() {
+            let file_name = f.path().file_name().unwrap().to_string_lossy();
+            if is_dev_only(&file_name, false) {
+                continue;
+            }
             let name = if prefix.is_empty() {
-                f.path().file_name().unwrap().to_string_lossy().into_owned()
+                file_name.into_owned()
             } else {
-                format!(
-                    "{}/{}",
-                    prefix,
-                    f.path().file_name().unwrap().to_string_lossy()
-                )
+                format!("{}/{}", prefix, file_name)
             };
             zip.start_file(name, opts)?;
             zip.write_all(f.contents())?;
         }
         for sub in dir.dirs() {
             let sub_name = sub.path().file_name().unwrap().to_string_lossy();
+            if is_dev_only(&sub_name, true) {
+                continue;
+            }
             let next_prefix = if prefix.is_empty() {
                 sub_name.into_owned()
             } else {
@@ -325,7 +354,9 @@ fn zip_dir_flat(d: &Dir<'_>) -> Result<Vec<u8>, std::io::Error> {
         Ok(())
     }
 
-    add(d, "", &mut zip, opts)?;
+    for (d, prefix) in dirs {
+        add(d, prefix, &mut zip, opts)?;
+    }
     zip.finish()?;
     Ok(buf.into_inner())
 }
@@ -23,6 +23,10 @@ use regex::Regex;
 use regex::escape;
 use util::execution_config::ExecutionConfig;
 
+/// Prefix of the annotation lines a memo run can follow each result line with
+/// (the Go starter's `-expect` mode). They are not output and are dropped.
+const EXPECT_PREFIX: &str = "# EXPECT ";
+
 /// Represents a parsed submission containing multiple tasks.
 #[derive(Debug)]
 pub struct Submission {
@@ -190,6 +194,7 @@ fn parse_task_output(
 
             let mut subtask_lines: Vec<String> = content_lines[start_line..end_line]
                 .iter()
+                .filter(|s| !s.starts_with(EXPECT_PREFIX))
                 .map(|s| s.to_string())
                 .collect();
 
@@ -352,6 +357,23 @@ line2"#;
         assert_eq!(task_output.subtasks[1].lines, vec!["line1", "line2"]);
     }
 
+    #[test]
+    fn test_parse_task_output_skips_expect_lines() {
+        let content = r#"go run . 1
+###Subtask1
+line1
+# EXPECT line1
+###Subtask2
+!!! notice
+line2
+# EXPECT line2"#;
+        let result = parse_task_output(content, 2, &ExecutionConfig::default_config());
+        assert!(result.is_ok());
+        let (task_output, _, _) = result.unwrap();
+        assert_eq!(task_output.subtasks[0].lines, vec!["line1"]);
+        assert_eq!(task_output.subtasks[1].lines, vec!["!!! notice", "line2"]);
+    }
+
     #[test]
     fn test_parse_task_output_empty_content() {
         let content = "";